@@ -0,0 +1,129 @@
+// Package codec implements a self-describing binary encoding which preserves the Go types of values in dg structures.
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// version is written before every encoded value so that the format can change.
+const version byte = 1
+
+// Tags written before each value to describe its type.
+const (
+	tagNil byte = iota
+	tagFalse
+	tagTrue
+	tagInt
+	tagInt8
+	tagInt16
+	tagInt32
+	tagInt64
+	tagUint
+	tagUint8
+	tagUint16
+	tagUint32
+	tagUint64
+	tagUintptr
+	tagFloat32
+	tagFloat64
+	tagComplex64
+	tagComplex128
+	tagString
+	tagBytes
+	tagContainer
+	tagType
+)
+
+// container is how a registered dg structure is encoded and decoded.
+type container struct {
+	name     string
+	elements func(interface{}) ([]interface{}, error)
+}
+
+var (
+	registryMu sync.RWMutex
+	containers = make(map[reflect.Type]container)
+	builders   = make(map[string]func([]interface{}) (interface{}, error))
+	types      = make(map[reflect.Type]string)
+	names      = make(map[string]reflect.Type)
+)
+
+/* RegisterContainer registers a structure with the type of the sample. Values of that type are encoded under the name as the elements returned by elements, and decoded by passing the elements to build. Several types may share a name, in which case they all decode to the value built by the last registration. */
+func RegisterContainer(name string, sample interface{}, elements func(interface{}) ([]interface{}, error), build func([]interface{}) (interface{}, error)) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	containers[reflect.TypeOf(sample)] = container{name, elements}
+	builders[name] = build
+}
+
+/* Register registers the type of the value under its type name, so that values of the type can be encoded. It panics if the name is already registered to a different type. */
+func Register(value interface{}) {
+	RegisterName(reflect.TypeOf(value).String(), value)
+}
+
+/* RegisterName registers the type of the value under the given name, so that values of the type can be encoded. The name must be the same wherever the value is encoded and decoded. It panics if the name or type is already registered differently. */
+func RegisterName(name string, value interface{}) {
+	t := reflect.TypeOf(value)
+	if t == nil {
+		panic("codec: cannot register nil")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if other, ok := names[name]; ok && other != t {
+		panic(fmt.Sprintf("codec: registering %v as %q, already registered as %v", t, name, other))
+	}
+	if other, ok := types[t]; ok && other != name {
+		panic(fmt.Sprintf("codec: registering %v as %q, already registered as %q", t, name, other))
+	}
+	types[t] = name
+	names[name] = t
+}
+
+/* Marshal encodes the value. Values may be nil, bools, numbers of any width, strings, byte slices, registered dg structures and registered types, which are encoded field by field unless they implement encoding.BinaryMarshaler. It returns an error for a registered struct with an unexported field which does not implement encoding.BinaryMarshaler. */
+func Marshal(value interface{}) ([]byte, error) {
+	e := &encoder{buf: []byte{version}}
+	if err := e.value(value); err != nil {
+		return nil, err
+	}
+	return e.buf, nil
+}
+
+/* Unmarshal decodes a value encoded by Marshal, with the same type it was encoded with. */
+func Unmarshal(data []byte) (interface{}, error) {
+	d, err := newDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+	value, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	return value, d.finish()
+}
+
+/* UnmarshalElements decodes a structure encoded by Marshal and returns its elements. It returns an error if the structure was not registered under one of the names. */
+func UnmarshalElements(data []byte, names ...string) ([]interface{}, error) {
+	d, err := newDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	if tag != tagContainer {
+		return nil, fmt.Errorf("Encoded value is not a structure")
+	}
+	name, elements, err := d.container()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range names {
+		if n == name {
+			return elements, d.finish()
+		}
+	}
+	return nil, fmt.Errorf("Cannot decode %s into %v", name, names)
+}
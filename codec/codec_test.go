@@ -0,0 +1,140 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type point struct {
+	X, Y  int16
+	Label string
+	Tags  []string
+	Attrs map[string]float32
+	Next  *point
+	Extra interface{}
+	When  time.Time
+}
+
+type celsius float64
+
+// edge has unexported fields, so it cannot be encoded field by field.
+type edge struct {
+	to     string
+	weight int
+}
+
+// secret has an unexported field but encodes itself.
+type secret struct {
+	value string
+}
+
+func (s secret) MarshalBinary() ([]byte, error) {
+	return []byte(s.value), nil
+}
+
+func (s *secret) UnmarshalBinary(data []byte) error {
+	s.value = string(data)
+	return nil
+}
+
+func init() {
+	Register(point{})
+	Register(&point{})
+	Register(celsius(0))
+	Register(edge{})
+	Register(secret{})
+}
+
+func TestScalars(t *testing.T) {
+	values := []interface{}{
+		nil, true, false, -1, int8(-8), int16(16), int32(-32), int64(1 << 62),
+		uint(1), uint8(8), uint16(16), uint32(32), uint64(1 << 63), uintptr(7),
+		float32(1.5), 2.25, complex64(1 + 2i), complex(3, -4), "text", []byte{0, 1, 2},
+	}
+	for _, value := range values {
+		b, err := Marshal(value)
+		if err != nil {
+			t.Errorf("Unexpected error encoding %v: %v", value, err)
+			continue
+		}
+		decoded, err := Unmarshal(b)
+		if err != nil {
+			t.Errorf("Unexpected error decoding %v: %v", value, err)
+		} else if !reflect.DeepEqual(decoded, value) {
+			t.Errorf("Expected %#v, got %#v", value, decoded)
+		}
+	}
+}
+
+func TestRegisteredTypes(t *testing.T) {
+	when := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	p := point{
+		X: 1, Y: -2, Label: "a", Tags: []string{"x"}, Attrs: map[string]float32{"w": 0.5},
+		Next: &point{Label: "b"}, Extra: uint8(3), When: when,
+	}
+	expected := p
+	for _, value := range []interface{}{p, &p, celsius(21.5)} {
+		b, err := Marshal(value)
+		if err != nil {
+			t.Fatalf("Unexpected error encoding %v: %v", value, err)
+		}
+		decoded, err := Unmarshal(b)
+		if err != nil {
+			t.Errorf("Unexpected error decoding %v: %v", value, err)
+		} else if reflect.TypeOf(decoded) != reflect.TypeOf(value) {
+			t.Errorf("Expected type %T, got %T", value, decoded)
+		}
+		switch d := decoded.(type) {
+		case point:
+			if !reflect.DeepEqual(d, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, d)
+			}
+		case *point:
+			if !reflect.DeepEqual(*d, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, *d)
+			}
+		case celsius:
+			if d != 21.5 {
+				t.Errorf("Expected 21.5, got %v", d)
+			}
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	type unregistered struct{}
+	if _, err := Marshal(unregistered{}); err == nil {
+		t.Error("Expected error for unregistered type")
+	} else if _, err := Marshal([]int{1}); err == nil {
+		t.Error("Expected error for unregistered slice")
+	}
+	if b, err := Marshal(edge{"b", 3}); err == nil {
+		t.Errorf("Expected error for unexported fields, got %v", b)
+	} else if b, err := Marshal(secret{"s"}); err != nil {
+		t.Error(err)
+	} else if decoded, err := Unmarshal(b); err != nil || decoded != (secret{"s"}) {
+		t.Errorf("Got %v and %v, was expecting the value to encode itself", decoded, err)
+	}
+	b, _ := Marshal("text")
+	if _, err := Unmarshal(b[:len(b)-1]); err == nil {
+		t.Error("Expected error for truncated data")
+	} else if _, err := Unmarshal(append(b, 0)); err == nil {
+		t.Error("Expected error for trailing data")
+	} else if _, err := Unmarshal([]byte{9, tagNil}); err == nil {
+		t.Error("Expected error for unknown version")
+	} else if _, err := Unmarshal([]byte{version, 200}); err == nil {
+		t.Error("Expected error for unknown tag")
+	} else if _, err := UnmarshalElements(b, "list"); err == nil {
+		t.Error("Expected error for value which is not a structure")
+	}
+}
+
+func TestRegisterConflict(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for conflicting registration")
+		}
+	}()
+	RegisterName("codec.point", celsius(0))
+}
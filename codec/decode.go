@@ -0,0 +1,348 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// decoder reads encoded values from data.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+/* newDecoder returns a decoder positioned after the version of the data. */
+func newDecoder(data []byte) (*decoder, error) {
+	d := &decoder{data: data}
+	v, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	if v != version {
+		return nil, fmt.Errorf("Unsupported encoding version %d", v)
+	}
+	return d, nil
+}
+
+/* finish returns an error if there is data after the decoded value. */
+func (d *decoder) finish() error {
+	if d.pos != len(d.data) {
+		return fmt.Errorf("Unexpected data after encoded value")
+	}
+	return nil
+}
+
+func (d *decoder) byte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, fmt.Errorf("Unexpected end of encoded data")
+	}
+	d.pos++
+	return d.data[d.pos-1], nil
+}
+
+func (d *decoder) uvarint() (uint64, error) {
+	u, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("Invalid encoded integer")
+	}
+	d.pos += n
+	return u, nil
+}
+
+func (d *decoder) varint() (int64, error) {
+	i, n := binary.Varint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("Invalid encoded integer")
+	}
+	d.pos += n
+	return i, nil
+}
+
+func (d *decoder) float() (float64, error) {
+	b, err := d.next(8)
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+}
+
+func (d *decoder) bytes() ([]byte, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{}, b...), nil
+}
+
+func (d *decoder) string() (string, error) {
+	n, err := d.length()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.next(n)
+	return string(b), err
+}
+
+/* length reads a length, which cannot be more than the remaining data. */
+func (d *decoder) length() (int, error) {
+	u, err := d.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	if u > uint64(len(d.data)-d.pos) {
+		return 0, fmt.Errorf("Unexpected end of encoded data")
+	}
+	return int(u), nil
+}
+
+/* next returns the next n bytes of data. */
+func (d *decoder) next(n int) ([]byte, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("Unexpected end of encoded data")
+	}
+	d.pos += n
+	return d.data[d.pos-n : d.pos], nil
+}
+
+/* value reads a tagged value. */
+func (d *decoder) value() (interface{}, error) {
+	tag, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case tagNil:
+		return nil, nil
+	case tagFalse:
+		return false, nil
+	case tagTrue:
+		return true, nil
+	case tagInt, tagInt8, tagInt16, tagInt32, tagInt64:
+		i, err := d.varint()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case tagInt:
+			return int(i), nil
+		case tagInt8:
+			return int8(i), nil
+		case tagInt16:
+			return int16(i), nil
+		case tagInt32:
+			return int32(i), nil
+		}
+		return i, nil
+	case tagUint, tagUint8, tagUint16, tagUint32, tagUint64, tagUintptr:
+		u, err := d.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		switch tag {
+		case tagUint:
+			return uint(u), nil
+		case tagUint8:
+			return uint8(u), nil
+		case tagUint16:
+			return uint16(u), nil
+		case tagUint32:
+			return uint32(u), nil
+		case tagUintptr:
+			return uintptr(u), nil
+		}
+		return u, nil
+	case tagFloat32, tagFloat64:
+		f, err := d.float()
+		if err != nil {
+			return nil, err
+		}
+		if tag == tagFloat32 {
+			return float32(f), nil
+		}
+		return f, nil
+	case tagComplex64, tagComplex128:
+		r, err := d.float()
+		if err != nil {
+			return nil, err
+		}
+		i, err := d.float()
+		if err != nil {
+			return nil, err
+		}
+		if tag == tagComplex64 {
+			return complex64(complex(r, i)), nil
+		}
+		return complex(r, i), nil
+	case tagString:
+		return d.string()
+	case tagBytes:
+		return d.bytes()
+	case tagContainer:
+		name, elements, err := d.container()
+		if err != nil {
+			return nil, err
+		}
+		registryMu.RLock()
+		build, ok := builders[name]
+		registryMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("Cannot decode unregistered structure %s", name)
+		}
+		return build(elements)
+	case tagType:
+		name, err := d.string()
+		if err != nil {
+			return nil, err
+		}
+		registryMu.RLock()
+		t, ok := names[name]
+		registryMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("Cannot decode unregistered type %s", name)
+		}
+		v := reflect.New(t).Elem()
+		if err = d.typed(v); err != nil {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+	return nil, fmt.Errorf("Invalid encoded tag %d", tag)
+}
+
+/* container reads the name and elements of a structure. */
+func (d *decoder) container() (string, []interface{}, error) {
+	name, err := d.string()
+	if err != nil {
+		return "", nil, err
+	}
+	n, err := d.length()
+	if err != nil {
+		return "", nil, err
+	}
+	elements := make([]interface{}, n)
+	for i := range elements {
+		if elements[i], err = d.value(); err != nil {
+			return "", nil, err
+		}
+	}
+	return name, elements, nil
+}
+
+/* typed reads a value of the type of v into v. */
+func (d *decoder) typed(v reflect.Value) error {
+	t := v.Type()
+	if usesBinary(t) {
+		b, err := d.bytes()
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(b)
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		b, err := d.byte()
+		v.SetBool(b != 0)
+		return err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := d.varint()
+		v.SetInt(i)
+		return err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := d.uvarint()
+		v.SetUint(u)
+		return err
+	case reflect.Float32, reflect.Float64:
+		f, err := d.float()
+		v.SetFloat(f)
+		return err
+	case reflect.Complex64, reflect.Complex128:
+		r, err := d.float()
+		if err != nil {
+			return err
+		}
+		i, err := d.float()
+		v.SetComplex(complex(r, i))
+		return err
+	case reflect.String:
+		s, err := d.string()
+		v.SetString(s)
+		return err
+	case reflect.Interface:
+		value, err := d.value()
+		if err != nil || value == nil {
+			return err
+		}
+		if !reflect.TypeOf(value).AssignableTo(t) {
+			return fmt.Errorf("Cannot decode %T into %v", value, t)
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	case reflect.Ptr:
+		present, err := d.byte()
+		if err != nil || present == 0 {
+			return err
+		}
+		p := reflect.New(t.Elem())
+		if err = d.typed(p.Elem()); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	case reflect.Slice, reflect.Map:
+		n, err := d.uvarint()
+		if err != nil || n == 0 {
+			return err
+		}
+		n--
+		if n > uint64(len(d.data)-d.pos) {
+			return fmt.Errorf("Unexpected end of encoded data")
+		}
+		if t.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(t, int(n), int(n)))
+			return d.elements(v)
+		}
+		v.Set(reflect.MakeMapWithSize(t, int(n)))
+		for i := uint64(0); i < n; i++ {
+			key := reflect.New(t.Key()).Elem()
+			if err = d.typed(key); err != nil {
+				return err
+			}
+			value := reflect.New(t.Elem()).Elem()
+			if err = d.typed(value); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+		return nil
+	case reflect.Array:
+		return d.elements(v)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				return fmt.Errorf("Cannot decode unexported field %s of %v without encoding.BinaryUnmarshaler", t.Field(i).Name, t)
+			}
+			if err := d.typed(v.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("Cannot decode value of type %v", t)
+}
+
+/* elements reads the elements of a slice or array into v. */
+func (d *decoder) elements(v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := d.typed(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
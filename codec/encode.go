@@ -0,0 +1,257 @@
+package codec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+var (
+	binaryMarshalerType   = reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()
+	binaryUnmarshalerType = reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()
+)
+
+/* usesBinary returns true if values of the type are encoded with their own MarshalBinary and UnmarshalBinary methods. */
+func usesBinary(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr || t.Kind() == reflect.Interface {
+		return false
+	}
+	p := reflect.PtrTo(t)
+	return p.Implements(binaryMarshalerType) && p.Implements(binaryUnmarshalerType)
+}
+
+// encoder appends encoded values to a buffer.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) byte(b byte) {
+	e.buf = append(e.buf, b)
+}
+
+func (e *encoder) uvarint(u uint64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutUvarint(b[:], u)]...)
+}
+
+func (e *encoder) varint(i int64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutVarint(b[:], i)]...)
+}
+
+func (e *encoder) float(f float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+	e.buf = append(e.buf, b[:]...)
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.uvarint(uint64(len(b)))
+	e.buf = append(e.buf, b...)
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+/* value writes the tag and encoding of a value whose type is not known to the decoder. */
+func (e *encoder) value(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		e.byte(tagNil)
+	case bool:
+		if v {
+			e.byte(tagTrue)
+		} else {
+			e.byte(tagFalse)
+		}
+	case int:
+		e.byte(tagInt)
+		e.varint(int64(v))
+	case int8:
+		e.byte(tagInt8)
+		e.varint(int64(v))
+	case int16:
+		e.byte(tagInt16)
+		e.varint(int64(v))
+	case int32:
+		e.byte(tagInt32)
+		e.varint(int64(v))
+	case int64:
+		e.byte(tagInt64)
+		e.varint(v)
+	case uint:
+		e.byte(tagUint)
+		e.uvarint(uint64(v))
+	case uint8:
+		e.byte(tagUint8)
+		e.uvarint(uint64(v))
+	case uint16:
+		e.byte(tagUint16)
+		e.uvarint(uint64(v))
+	case uint32:
+		e.byte(tagUint32)
+		e.uvarint(uint64(v))
+	case uint64:
+		e.byte(tagUint64)
+		e.uvarint(v)
+	case uintptr:
+		e.byte(tagUintptr)
+		e.uvarint(uint64(v))
+	case float32:
+		e.byte(tagFloat32)
+		e.float(float64(v))
+	case float64:
+		e.byte(tagFloat64)
+		e.float(v)
+	case complex64:
+		e.byte(tagComplex64)
+		e.float(float64(real(v)))
+		e.float(float64(imag(v)))
+	case complex128:
+		e.byte(tagComplex128)
+		e.float(real(v))
+		e.float(imag(v))
+	case string:
+		e.byte(tagString)
+		e.string(v)
+	case []byte:
+		e.byte(tagBytes)
+		e.bytes(v)
+	default:
+		return e.registered(value)
+	}
+	return nil
+}
+
+/* registered writes a value of a registered structure or type. */
+func (e *encoder) registered(value interface{}) error {
+	t := reflect.TypeOf(value)
+	registryMu.RLock()
+	c, isContainer := containers[t]
+	name, isType := types[t]
+	registryMu.RUnlock()
+	if isContainer {
+		elements, err := c.elements(value)
+		if err != nil {
+			return err
+		}
+		e.byte(tagContainer)
+		e.string(c.name)
+		e.uvarint(uint64(len(elements)))
+		for _, element := range elements {
+			if err = e.value(element); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if isType {
+		e.byte(tagType)
+		e.string(name)
+		return e.typed(reflect.ValueOf(value))
+	}
+	return fmt.Errorf("Cannot encode value of unregistered type %T", value)
+}
+
+/* typed writes a value whose type is known to the decoder, so no tag is needed. */
+func (e *encoder) typed(v reflect.Value) error {
+	t := v.Type()
+	if usesBinary(t) {
+		return e.marshaler(v)
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			e.byte(1)
+		} else {
+			e.byte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.varint(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.uvarint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		e.float(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		e.float(real(v.Complex()))
+		e.float(imag(v.Complex()))
+	case reflect.String:
+		e.string(v.String())
+	case reflect.Interface:
+		return e.value(v.Interface())
+	case reflect.Ptr:
+		if v.IsNil() {
+			e.byte(0)
+			return nil
+		}
+		e.byte(1)
+		return e.typed(v.Elem())
+	case reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			e.uvarint(0)
+			return nil
+		}
+		e.uvarint(uint64(v.Len()) + 1)
+		if t.Kind() == reflect.Map {
+			iter := v.MapRange()
+			for iter.Next() {
+				if err := e.typed(iter.Key()); err != nil {
+					return err
+				}
+				if err := e.typed(iter.Value()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return e.elements(v)
+	case reflect.Array:
+		return e.elements(v)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				return fmt.Errorf("Cannot encode unexported field %s of %v without encoding.BinaryMarshaler", t.Field(i).Name, t)
+			}
+			if err := e.typed(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("Cannot encode value of type %v", t)
+	}
+	return nil
+}
+
+/* elements writes the elements of a slice or array. */
+func (e *encoder) elements(v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := e.typed(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* marshaler writes the output of a value's MarshalBinary method. */
+func (e *encoder) marshaler(v reflect.Value) error {
+	m, ok := v.Interface().(encoding.BinaryMarshaler)
+	if !ok {
+		// MarshalBinary has a pointer receiver, so marshal through a pointer.
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		if v.CanAddr() {
+			p = v.Addr()
+		}
+		m = p.Interface().(encoding.BinaryMarshaler)
+	}
+	b, err := m.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	e.bytes(b)
+	return nil
+}
@@ -0,0 +1,388 @@
+// Package counter implements counters, which are multisets counting how many times each hashable value occurs.
+package counter
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dynago/dg/dict"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/tuple"
+)
+
+// Counter is a dict of values to their integer counts, modelled on Python's collections.Counter. Missing values have a count of 0. Counts may be zero or negative after Add, Set or Subtract, but the arithmetic between counters keeps only positive counts. Values are kept in the order they were first counted.
+type Counter struct {
+	counts dict.DictInterface
+}
+
+/* Length returns the number of distinct values in counter. */
+func (c *Counter) Length() int {
+	return c.counts.Length()
+}
+
+/* Iterate returns the next distinct value in counter. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (c *Counter) Iterate() <-chan interface{} {
+	return iterable.Channel(c)
+}
+
+/* ForEach calls the function on each distinct value in counter, in the order they were first counted, until it returns false. */
+func (c *Counter) ForEach(fn func(interface{}) bool) {
+	c.counts.ForEach(fn)
+}
+
+/* Iterator returns an Iterator over the distinct values in counter. */
+func (c *Counter) Iterator() iterable.Iterator {
+	return c.counts.Iterator()
+}
+
+/* Get returns the count of the value, which is 0 if it has not been counted. */
+func (c *Counter) Get(value interface{}) (int, error) {
+	count, err := c.counts.Get(value)
+	if err != nil || count == nil {
+		return 0, err
+	}
+	n, ok := count.(int)
+	if !ok {
+		return 0, &errs.TypeError{Value: count, Key: value, Expected: "an integer"}
+	}
+	return n, nil
+}
+
+/* Set sets the count of the value. */
+func (c *Counter) Set(value interface{}, count int) error {
+	return c.counts.Set(value, count)
+}
+
+/* Increment adds one to the count of the value. */
+func (c *Counter) Increment(value interface{}) error {
+	return c.Add(value, 1)
+}
+
+/* Add adds n to the count of the value. n may be negative. */
+func (c *Counter) Add(value interface{}, n int) error {
+	count, err := c.Get(value)
+	if err != nil {
+		return err
+	}
+	return c.counts.Set(value, count+n)
+}
+
+/* Update adds the counts of the other counter to the counter. */
+func (c *Counter) Update(other *Counter) error {
+	return c.combine(other, 1)
+}
+
+/* Subtract subtracts the counts of the other counter from the counter. Counts may become zero or negative. */
+func (c *Counter) Subtract(other *Counter) error {
+	return c.combine(other, -1)
+}
+
+/* Remove removes the value and its count from the counter. */
+func (c *Counter) Remove(value interface{}) error {
+	return c.counts.Remove(value)
+}
+
+/* Clear clears all counts from the counter. */
+func (c *Counter) Clear() error {
+	return c.counts.Clear()
+}
+
+/* Contains tests whether the value has a count in the counter, even one which is not positive. */
+func (c *Counter) Contains(value interface{}) (bool, error) {
+	return c.counts.Contains(value)
+}
+
+/* Equals returns true if the counter has the same counts as the other counter. Missing values count as 0, so a value with a count of 0 does not make counters unequal. */
+func (c *Counter) Equals(other *Counter) (bool, error) {
+	equals := true
+	var err error
+	check := func(a *Counter, b *Counter) {
+		a.forEachCount(func(value interface{}, count int) bool {
+			var o int
+			if o, err = b.Get(value); err != nil {
+				return false
+			}
+			equals = count == o
+			return equals
+		})
+	}
+	if check(c, other); equals && err == nil {
+		check(other, c)
+	}
+	if err != nil {
+		return false, err
+	}
+	return equals, nil
+}
+
+/* EqualTo returns true if the other value is a counter with the same counts. */
+func (c *Counter) EqualTo(other interface{}) bool {
+	o, ok := other.(*Counter)
+	if !ok {
+		return false
+	}
+	equals, err := c.Equals(o)
+	return err == nil && equals
+}
+
+/* Total returns the sum of the counts. */
+func (c *Counter) Total() int {
+	total := 0
+	c.forEachCount(func(value interface{}, count int) bool {
+		total += count
+		return true
+	})
+	return total
+}
+
+/* MostCommon returns a tuple of the n most common values and their counts as (value count) tuples, from the most common to the least. Values with equal counts are in the order they were first counted. If n is negative every value is returned. */
+func (c *Counter) MostCommon(n int) (tuple.TupleInterface, error) {
+	values := make([]interface{}, 0, c.Length())
+	counts := make([]int, 0, c.Length())
+	c.forEachCount(func(value interface{}, count int) bool {
+		values = append(values, value)
+		counts = append(counts, count)
+		return true
+	})
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return counts[indexes[i]] > counts[indexes[j]]
+	})
+	if n < 0 || n > len(indexes) {
+		n = len(indexes)
+	}
+	items := make([]interface{}, n)
+	for i := range items {
+		item, err := tuple.MakeTupleFromValues(values[indexes[i]], counts[indexes[i]])
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return tuple.MakeTupleFromValues(items...)
+}
+
+/* Elements returns a list with each value repeated as many times as its count, in the order the values were first counted. Values with counts which are not positive are left out. */
+func (c *Counter) Elements() (list.ListInterface, error) {
+	output, err := list.MakeList()
+	if err != nil {
+		return nil, err
+	}
+	c.forEachCount(func(value interface{}, count int) bool {
+		for i := 0; i < count && err == nil; i++ {
+			err = output.Append(value)
+		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* Sum returns a new counter with the counts of the counter and the other counter added, keeping only positive counts. */
+func (c *Counter) Sum(other *Counter) (*Counter, error) {
+	return c.merge(other, func(a int, b int) int {
+		return a + b
+	})
+}
+
+/* Difference returns a new counter with the counts of the other counter subtracted from the counts of the counter, keeping only positive counts. */
+func (c *Counter) Difference(other *Counter) (*Counter, error) {
+	return c.merge(other, func(a int, b int) int {
+		return a - b
+	})
+}
+
+/* Intersection returns a new counter with the minimum of the counts of each value in the counter and the other counter, keeping only positive counts. */
+func (c *Counter) Intersection(other *Counter) (*Counter, error) {
+	return c.merge(other, func(a int, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	})
+}
+
+/* Union returns a new counter with the maximum of the counts of each value in the counter and the other counter, keeping only positive counts. */
+func (c *Counter) Union(other *Counter) (*Counter, error) {
+	return c.merge(other, func(a int, b int) int {
+		if a > b {
+			return a
+		}
+		return b
+	})
+}
+
+/* ToDict returns a new dict of each value to its count. */
+func (c *Counter) ToDict() (dict.DictInterface, error) {
+	return c.counts.Copy()
+}
+
+/* Copy creates a copy of the counter. */
+func (c *Counter) Copy() (*Counter, error) {
+	counts, err := c.counts.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Counter{counts: counts}, nil
+}
+
+/* String returns a string representation of the counter, with each value and its count. */
+func (c *Counter) String() string {
+	output := "{"
+	c.forEachCount(func(value interface{}, count int) bool {
+		output += fmt.Sprintf("(%v %d) ", value, count)
+		return true
+	})
+	output = strings.Trim(output, " ") + "}"
+	return output
+}
+
+/* forEachCount calls the function on each value and its count until it returns false. Every count is stored as an int, and anything else counts as 0. */
+func (c *Counter) forEachCount(fn func(interface{}, int) bool) {
+	c.counts.ForEach(func(value interface{}) bool {
+		count, _ := c.counts.Get(value)
+		n, _ := count.(int)
+		return fn(value, n)
+	})
+}
+
+/* combine adds the counts of the other counter, multiplied by sign, to the counter. */
+func (c *Counter) combine(other *Counter, sign int) error {
+	var err error
+	other.forEachCount(func(value interface{}, count int) bool {
+		err = c.Add(value, sign*count)
+		return err == nil
+	})
+	return err
+}
+
+/* merge returns a new counter with the result of the function on the counts of each value in the counter and the other counter, keeping only positive results. Values in the counter come first. */
+func (c *Counter) merge(other *Counter, fn func(a int, b int) int) (*Counter, error) {
+	output, err := MakeCounter()
+	if err != nil {
+		return nil, err
+	}
+	keep := func(value interface{}, count int) error {
+		if count > 0 {
+			return output.Set(value, count)
+		}
+		return nil
+	}
+	c.forEachCount(func(value interface{}, count int) bool {
+		var o int
+		if o, err = other.Get(value); err != nil {
+			return false
+		}
+		err = keep(value, fn(count, o))
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	other.forEachCount(func(value interface{}, count int) bool {
+		var ok bool
+		if ok, err = c.Contains(value); err != nil || ok {
+			return err == nil
+		}
+		err = keep(value, fn(0, count))
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* Init initializes the counter. */
+func (c *Counter) Init() {
+	c.counts, _ = dict.MakeDict()
+}
+
+/* MakeCounter initializes a new counter object which counts the values in an Iterable. */
+func MakeCounter(it ...iterable.Iterable) (*Counter, error) {
+	output := new(Counter)
+	output.Init()
+	if len(it) > 0 {
+		var err error
+		iterable.ForEach(it[0], func(value interface{}) bool {
+			err = output.Increment(value)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+/* MakeCounterFromValues initializes a new counter object which counts any number of values. */
+func MakeCounterFromValues(values ...interface{}) (*Counter, error) {
+	output := new(Counter)
+	output.Init()
+	for _, value := range values {
+		if err := output.Increment(value); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+/* MakeCounterFromDict initializes a new counter object with the counts in a dict. Every value in the dict must be an integer which fits in an int, or an *errs.TypeError is returned. Counts are stored as ints. */
+func MakeCounterFromDict(d dict.DictInterface) (*Counter, error) {
+	output := new(Counter)
+	output.Init()
+	var err error
+	d.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = d.Get(key); err != nil {
+			return false
+		}
+		count, ok := toInt(value)
+		if !ok {
+			err = &errs.TypeError{Value: value, Key: key, Expected: "an integer"}
+			return false
+		}
+		err = output.Set(key, count)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* toInt returns the value as an int if it is an integer which fits in an int. */
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), v <= math.MaxInt
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), v <= math.MaxInt
+	}
+	return 0, false
+}
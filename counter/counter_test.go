@@ -0,0 +1,156 @@
+package counter
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/dynago/dg/dict"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/list"
+)
+
+func TestCount(t *testing.T) {
+	l, _ := list.MakeListFromValues("a", "b", "a", 1, "c", "a", 1, "b")
+	c, err := MakeCounter(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.String() != "{(a 3) (b 2) (1 2) (c 1)}" {
+		t.Fatalf("Got %s, which was unexpected", c.String())
+	} else if c.Length() != 4 || c.Total() != 8 {
+		t.Fatalf("Got length %d and total %d, was expecting 4 and 8", c.Length(), c.Total())
+	}
+
+	if count, err := c.Get("z"); err != nil || count != 0 {
+		t.Fatalf("Got %d, was expecting 0", count)
+	} else if ok, err := c.Contains("z"); err != nil || ok {
+		t.Fatal("Expected z not to be counted")
+	}
+
+	if err := c.Increment("z"); err != nil {
+		t.Error(err)
+	} else if err := c.Add("c", 4); err != nil {
+		t.Error(err)
+	} else if err := c.Add("b", -3); err != nil {
+		t.Error(err)
+	} else if c.String() != "{(a 3) (b -1) (1 2) (c 5) (z 1)}" {
+		t.Fatalf("Got %s, which was unexpected", c.String())
+	}
+
+	if err := c.Increment(nil); err == nil {
+		t.Fatal("Expected error counting nil")
+	}
+
+	if err := c.Remove("z"); err != nil {
+		t.Error(err)
+	} else if err := c.Set("a", 0); err != nil {
+		t.Error(err)
+	} else if c.Total() != 6 {
+		t.Fatalf("Got total %d, was expecting 6", c.Total())
+	}
+
+	if err := c.Clear(); err != nil || c.Length() != 0 {
+		t.Fatal("Expected counter to be empty")
+	}
+}
+
+func TestMostCommon(t *testing.T) {
+	c, _ := MakeCounterFromValues("x", "y", "z", "y", "z", "w", "z")
+
+	if common, err := c.MostCommon(2); err != nil {
+		t.Error(err)
+	} else if common.String() != "((z 3) (y 2))" {
+		t.Fatalf("Got %s, which was unexpected", common.String())
+	} else if common, err := c.MostCommon(-1); err != nil {
+		t.Error(err)
+	} else if common.String() != "((z 3) (y 2) (x 1) (w 1))" {
+		t.Fatalf("Got %s, which was unexpected", common.String())
+	} else if common, err := c.MostCommon(10); err != nil || common.Length() != 4 {
+		t.Fatalf("Got %v, which was unexpected", common)
+	}
+
+	c.Add("w", -2)
+	if elements, err := c.Elements(); err != nil {
+		t.Error(err)
+	} else if elements.String() != "[x y y z z z]" {
+		t.Fatalf("Got %s, which was unexpected", elements.String())
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	c1, _ := MakeCounterFromValues("a", "a", "a", "b", "c")
+	c2, _ := MakeCounterFromValues("a", "b", "b", "d")
+	c2.Add("c", -1)
+
+	tests := []struct {
+		name     string
+		fn       func(*Counter) (*Counter, error)
+		expected string
+	}{
+		{"Sum", c1.Sum, "{(a 4) (b 3) (d 1)}"},
+		{"Difference", c1.Difference, "{(a 2) (c 2)}"},
+		{"Intersection", c1.Intersection, "{(a 1) (b 1)}"},
+		{"Union", c1.Union, "{(a 3) (b 2) (c 1) (d 1)}"},
+	}
+	for _, test := range tests {
+		if output, err := test.fn(c2); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if output.String() != test.expected {
+			t.Errorf("%s: got %s, was expecting %s", test.name, output.String(), test.expected)
+		}
+	}
+
+	if c1.String() != "{(a 3) (b 1) (c 1)}" {
+		t.Fatalf("Expected counter to be unchanged, got %s", c1.String())
+	}
+
+	c3, _ := c1.Copy()
+	if err := c3.Update(c2); err != nil {
+		t.Error(err)
+	} else if c3.String() != "{(a 4) (b 3) (c 0) (d 1)}" {
+		t.Fatalf("Got %s, which was unexpected", c3.String())
+	} else if err := c3.Subtract(c2); err != nil {
+		t.Error(err)
+	} else if !c3.EqualTo(c1) {
+		t.Fatalf("Got %s, was expecting %s", c3.String(), c1.String())
+	}
+}
+
+func TestDict(t *testing.T) {
+	d, _ := dict.MakeDictFromKeyValues([]interface{}{"a", 2}, []interface{}{3, int64(1)})
+	c, err := MakeCounterFromDict(d)
+	if err != nil {
+		t.Fatal(err)
+	} else if count, _ := c.Get(2); count != 1 {
+		t.Fatalf("Got %d, was expecting 1", count)
+	}
+
+	var typeErr *errs.TypeError
+	d.Set("b", "many")
+	if _, err := MakeCounterFromDict(d); !errors.As(err, &typeErr) {
+		t.Fatalf("Got %v, was expecting a TypeError for count which is not an integer", err)
+	} else if typeErr.Value != "many" || typeErr.Key != "b" || !errors.Is(err, errs.ErrWrongType) {
+		t.Fatalf("Got %+v, was expecting count many of b", typeErr)
+	}
+	d.Set("b", uint64(math.MaxUint64))
+	if _, err := MakeCounterFromDict(d); !errors.Is(err, errs.ErrWrongType) {
+		t.Fatalf("Got %v, was expecting an error for count which does not fit in an int", err)
+	}
+	d.Set("b", uint8(2))
+	if c, err := MakeCounterFromDict(d); err != nil {
+		t.Error(err)
+	} else if count, err := c.Get("b"); err != nil || count != 2 {
+		t.Fatalf("Got %v, was expecting the count stored as an int", count)
+	}
+
+	output, err := c.ToDict()
+	if err != nil {
+		t.Error(err)
+	} else if value, _ := output.Get("a"); value != 3 {
+		t.Fatalf("Got %v, was expecting 3", value)
+	} else if output.Set("a", 5); c.String() != "{(a 3) (2 1)}" {
+		t.Fatalf("Expected counter to be unchanged, got %s", c.String())
+	}
+}
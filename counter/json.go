@@ -0,0 +1,30 @@
+package counter
+
+import (
+	"encoding/json"
+
+	"github.com/dynago/dg/dict"
+	"github.com/dynago/dg/internal/jsonvalue"
+)
+
+/* MarshalJSON encodes the counter as a JSON object of values to counts. */
+func (c *Counter) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.counts)
+}
+
+/* UnmarshalJSON decodes a JSON object of values to integer counts into the counter. */
+func (c *Counter) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	d := new(dict.Dict)
+	if err := json.Unmarshal(data, d); err != nil {
+		return err
+	}
+	output, err := MakeCounterFromDict(d)
+	if err != nil {
+		return err
+	}
+	c.counts = output.counts
+	return nil
+}
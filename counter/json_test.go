@@ -0,0 +1,24 @@
+package counter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	c, _ := MakeCounterFromValues("b", "a", "b")
+	if b, err := json.Marshal(c); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"b":2,"a":1}` {
+		t.Errorf("Expected object, got %s", b)
+	}
+
+	decoded := new(Counter)
+	if err := json.Unmarshal([]byte(`{"x": 2, "y": 1}`), decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if decoded.String() != "{(x 2) (y 1)}" {
+		t.Errorf("Got %s, which was unexpected", decoded.String())
+	} else if err := json.Unmarshal([]byte(`{"x": 1.5}`), decoded); err == nil {
+		t.Error("Expected error for count which is not an integer")
+	}
+}
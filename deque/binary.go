@@ -0,0 +1,44 @@
+package deque
+
+import (
+	"encoding/gob"
+
+	"github.com/dynago/dg/codec"
+)
+
+func init() {
+	codec.RegisterContainer("deque", (*Deque)(nil), binaryElements, func(values []interface{}) (interface{}, error) {
+		return MakeDequeFromValues(values...)
+	})
+	gob.Register(new(Deque))
+}
+
+/* MarshalBinary encodes the deque, preserving the type of every element. The maximum length is not encoded. */
+func (d *Deque) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(d)
+}
+
+/* UnmarshalBinary decodes a deque encoded by MarshalBinary into the deque, keeping its maximum length. */
+func (d *Deque) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "deque")
+	if err != nil {
+		return err
+	}
+	d.fill(values)
+	return nil
+}
+
+/* GobEncode encodes the deque for encoding/gob. */
+func (d *Deque) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+/* GobDecode decodes the deque for encoding/gob. */
+func (d *Deque) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* binaryElements returns the values of the deque to encode. */
+func binaryElements(d interface{}) ([]interface{}, error) {
+	return d.(*Deque).snapshot(), nil
+}
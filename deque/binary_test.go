@@ -0,0 +1,40 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/dynago/dg/codec"
+)
+
+func TestBinary(t *testing.T) {
+	d, _ := MakeDequeFromValues(int8(1), "a", 2.5)
+	d.Rotate(1)
+	b, err := d.(*Deque).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := new(Deque)
+	if err = decoded.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if equals, _ := decoded.Equals(d); !equals {
+		t.Errorf("Expected %v, got %v", d, decoded)
+	}
+	if v, err := codec.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if _, ok := v.(*Deque); !ok {
+		t.Errorf("Expected deque, got %T", v)
+	}
+
+	var buf bytes.Buffer
+	var in, out interface{}
+	in = d
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if out.(*Deque).String() != "[2.5 1 a]" {
+		t.Errorf("Expected %v, got %v", d, out)
+	}
+}
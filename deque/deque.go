@@ -0,0 +1,343 @@
+// Package deque implements deques, which are double-ended queues supporting appends and pops at both ends in O(1) time.
+package deque
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/internal/helpers"
+	"github.com/dynago/dg/iterable"
+)
+
+// minCapacity is the smallest ring buffer a deque allocates.
+const minCapacity = 8
+
+// Deque is a dynamic double-ended queue backed by a ring buffer. A deque with a maximum length drops values from the opposite end when appending to a full deque.
+type Deque struct {
+	values []interface{} // ring buffer, which grows by doubling when full
+	head   int           // index in values of the leftmost value
+	length int
+	maxLen int // maximum length, or 0 if unbounded
+}
+
+/* Length returns the number of elements in deque. */
+func (d *Deque) Length() int {
+	return d.length
+}
+
+/* Iterate returns the next value in deque. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (d *Deque) Iterate() <-chan interface{} {
+	return iterable.Channel(d)
+}
+
+/* ForEach calls the function on each value in deque, from left to right, until it returns false. */
+func (d *Deque) ForEach(fn func(interface{}) bool) {
+	for i := 0; i < d.length; i++ {
+		if !fn(d.values[d.index(i)]) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the values in deque, from left to right. */
+func (d *Deque) Iterator() iterable.Iterator {
+	return iterable.FromSlice(d.snapshot())
+}
+
+/* Contains tests for membership in the deque. */
+func (d *Deque) Contains(value interface{}) (bool, error) {
+	for i := 0; i < d.length; i++ {
+		if equality.Equal(d.values[d.index(i)], value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+/* Equals returns true if the deque has all elements in common with the other deque, in the same order. */
+func (d *Deque) Equals(other DequeInterface) (bool, error) {
+	if d.Length() != other.Length() {
+		return false, nil
+	}
+	equals := true
+	i := 0
+	other.ForEach(func(value interface{}) bool {
+		equals = i < d.length && equality.Equal(d.values[d.index(i)], value)
+		i += 1
+		return equals
+	})
+	return equals, nil
+}
+
+/* EqualTo returns true if the other value is a deque with equal elements in the same order. */
+func (d *Deque) EqualTo(other interface{}) bool {
+	o, ok := other.(DequeInterface)
+	if !ok {
+		return false
+	}
+	equals, err := d.Equals(o)
+	return err == nil && equals
+}
+
+/* Get returns the value at index. Negative indexes count from the right of the deque. */
+func (d *Deque) Get(i int) (interface{}, error) {
+	j, ok := helpers.ElementIndex(i, d.length)
+	if !ok {
+		return nil, &errs.IndexError{Index: i, Length: d.length, Structure: "deque"}
+	}
+	return d.values[d.index(j)], nil
+}
+
+/* Set sets the value at index. Negative indexes count from the right of the deque. */
+func (d *Deque) Set(i int, value interface{}) error {
+	j, ok := helpers.ElementIndex(i, d.length)
+	if !ok {
+		return &errs.IndexError{Index: i, Length: d.length, Structure: "deque"}
+	}
+	d.values[d.index(j)] = value
+	return nil
+}
+
+/* Peek returns the rightmost value without removing it. */
+func (d *Deque) Peek() (interface{}, error) {
+	if d.length == 0 {
+		return nil, &errs.EmptyError{Op: "peek at", Structure: "deque"}
+	}
+	return d.values[d.index(d.length-1)], nil
+}
+
+/* PeekLeft returns the leftmost value without removing it. */
+func (d *Deque) PeekLeft() (interface{}, error) {
+	if d.length == 0 {
+		return nil, &errs.EmptyError{Op: "peek at", Structure: "deque"}
+	}
+	return d.values[d.head], nil
+}
+
+/* Append appends element to the right of the deque. If the deque is at its maximum length the leftmost value is dropped. */
+func (d *Deque) Append(value interface{}) error {
+	if d.maxLen > 0 && d.length == d.maxLen {
+		d.popLeft()
+	}
+	d.pushRight(value)
+	return nil
+}
+
+/* AppendLeft appends element to the left of the deque. If the deque is at its maximum length the rightmost value is dropped. */
+func (d *Deque) AppendLeft(value interface{}) error {
+	if d.maxLen > 0 && d.length == d.maxLen {
+		d.popRight()
+	}
+	d.pushLeft(value)
+	return nil
+}
+
+/* Extend appends the values in the iterable to the right of the deque. */
+func (d *Deque) Extend(it iterable.Iterable) error {
+	values := collect(it)
+	for _, value := range values {
+		d.Append(value)
+	}
+	return nil
+}
+
+/* ExtendLeft appends the values in the iterable to the left of the deque, one at a time, so they end up in reverse order. */
+func (d *Deque) ExtendLeft(it iterable.Iterable) error {
+	values := collect(it)
+	for _, value := range values {
+		d.AppendLeft(value)
+	}
+	return nil
+}
+
+/* Pop pops and returns the rightmost element from the deque. */
+func (d *Deque) Pop() (interface{}, error) {
+	if d.length == 0 {
+		return nil, &errs.EmptyError{Op: "pop from", Structure: "deque"}
+	}
+	return d.popRight(), nil
+}
+
+/* PopLeft pops and returns the leftmost element from the deque. */
+func (d *Deque) PopLeft() (interface{}, error) {
+	if d.length == 0 {
+		return nil, &errs.EmptyError{Op: "pop from", Structure: "deque"}
+	}
+	return d.popLeft(), nil
+}
+
+/* Rotate rotates the deque n steps to the right, moving the rightmost values to the left, or to the left if n is negative. It takes O(min(|n|, Length())) time. */
+func (d *Deque) Rotate(n int) {
+	if d.length < 2 {
+		return
+	}
+	n %= d.length
+	if n < 0 {
+		n += d.length
+	}
+	if n > d.length/2 {
+		for i := n; i < d.length; i++ {
+			d.pushRight(d.popLeft())
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		d.pushLeft(d.popRight())
+	}
+}
+
+/* Clear clears all elements from the deque. */
+func (d *Deque) Clear() error {
+	d.Init()
+	return nil
+}
+
+/* MaxLen returns the maximum length of the deque, or 0 if it is unbounded. */
+func (d *Deque) MaxLen() int {
+	return d.maxLen
+}
+
+/* Copy creates a copy of the current DequeInterface with the same maximum length. */
+func (d *Deque) Copy() (DequeInterface, error) {
+	output := &Deque{maxLen: d.maxLen, length: d.length}
+	output.values = make([]interface{}, d.length, d.length+minCapacity)
+	copy(output.values, d.snapshot())
+	output.values = output.values[:cap(output.values)]
+	return output, nil
+}
+
+/* String returns a string representation of the deque, from left to right. */
+func (d *Deque) String() string {
+	output := "["
+	d.ForEach(func(value interface{}) bool {
+		output += fmt.Sprintf("%v ", value)
+		return true
+	})
+	output = strings.Trim(output, " ") + "]"
+	return output
+}
+
+/* index returns the index in the ring buffer of the i-th value from the left. */
+func (d *Deque) index(i int) int {
+	return (d.head + i) % len(d.values)
+}
+
+/* grow doubles the ring buffer if it is full, moving the values to its start. */
+func (d *Deque) grow() {
+	if d.length < len(d.values) {
+		return
+	}
+	capacity := 2 * len(d.values)
+	if capacity < minCapacity {
+		capacity = minCapacity
+	}
+	values := make([]interface{}, capacity)
+	for i := 0; i < d.length; i++ {
+		values[i] = d.values[d.index(i)]
+	}
+	d.values = values
+	d.head = 0
+}
+
+/* pushRight adds the value to the right of the deque, ignoring the maximum length. */
+func (d *Deque) pushRight(value interface{}) {
+	d.grow()
+	d.values[d.index(d.length)] = value
+	d.length += 1
+}
+
+/* pushLeft adds the value to the left of the deque, ignoring the maximum length. */
+func (d *Deque) pushLeft(value interface{}) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.values)) % len(d.values)
+	d.values[d.head] = value
+	d.length += 1
+}
+
+/* popRight removes and returns the rightmost value, which must exist. */
+func (d *Deque) popRight() interface{} {
+	i := d.index(d.length - 1)
+	value := d.values[i]
+	d.values[i] = nil
+	d.length -= 1
+	return value
+}
+
+/* popLeft removes and returns the leftmost value, which must exist. */
+func (d *Deque) popLeft() interface{} {
+	value := d.values[d.head]
+	d.values[d.head] = nil
+	d.head = (d.head + 1) % len(d.values)
+	d.length -= 1
+	return value
+}
+
+/* snapshot returns the values in the deque from left to right. */
+func (d *Deque) snapshot() []interface{} {
+	values := make([]interface{}, 0, d.length)
+	d.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+/* fill replaces the values in the deque with the values, keeping only the last ones if there are more than the maximum length. */
+func (d *Deque) fill(values []interface{}) {
+	d.Init()
+	for _, value := range values {
+		d.Append(value)
+	}
+}
+
+/* collect returns the values in the iterable. The values are collected before any is appended, so a deque can be extended by itself. */
+func collect(it iterable.Iterable) []interface{} {
+	values := make([]interface{}, 0)
+	iterable.ForEach(it, func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+/* Init initializes the deque, keeping its maximum length. */
+func (d *Deque) Init() {
+	d.values = make([]interface{}, minCapacity)
+	d.head = 0
+	d.length = 0
+}
+
+/* MakeDeque initializes a new unbounded deque object using an Iterable object. */
+func MakeDeque(it ...iterable.Iterable) (DequeInterface, error) {
+	output := new(Deque)
+	output.Init()
+	if len(it) > 0 {
+		output.Extend(it[0])
+	}
+	return output, nil
+}
+
+/* MakeDequeWithMaxLen initializes a new deque object which holds at most maxLen values using an Iterable object. Only the last maxLen values of the iterable are kept. */
+func MakeDequeWithMaxLen(maxLen int, it ...iterable.Iterable) (DequeInterface, error) {
+	if maxLen < 1 {
+		return nil, fmt.Errorf("Maximum length must be at least 1")
+	}
+	output := &Deque{maxLen: maxLen}
+	output.Init()
+	if len(it) > 0 {
+		output.Extend(it[0])
+	}
+	return output, nil
+}
+
+/* MakeDequeFromValues initializes a new unbounded deque object using any number of values */
+func MakeDequeFromValues(values ...interface{}) (DequeInterface, error) {
+	output := new(Deque)
+	output.Init()
+	for _, val := range values {
+		output.pushRight(val)
+	}
+	return output, nil
+}
@@ -0,0 +1,205 @@
+package deque
+
+import (
+	"testing"
+
+	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/set"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestAppendPop(t *testing.T) {
+	d, err := MakeDeque()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		if err := d.Append(i); err != nil {
+			t.Error(err)
+		} else if err := d.AppendLeft(-i - 1); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if d.Length() != 40 {
+		t.Fatalf("Got length %d, was expecting 40", d.Length())
+	} else if value, err := d.PeekLeft(); err != nil || value != -20 {
+		t.Fatalf("Got %v, was expecting -20", value)
+	} else if value, err := d.Peek(); err != nil || value != 19 {
+		t.Fatalf("Got %v, was expecting 19", value)
+	}
+
+	for i := 19; i >= 0; i-- {
+		if value, err := d.Pop(); err != nil || value != i {
+			t.Fatalf("Got %v, was expecting %d", value, i)
+		} else if value, err := d.PopLeft(); err != nil || value != -i-1 {
+			t.Fatalf("Got %v, was expecting %d", value, -i-1)
+		}
+	}
+
+	if _, err := d.Pop(); err == nil {
+		t.Fatal("Expected error popping from empty deque")
+	} else if _, err := d.PopLeft(); err == nil {
+		t.Fatal("Expected error popping from empty deque")
+	} else if _, err := d.Peek(); err == nil {
+		t.Fatal("Expected error peeking at empty deque")
+	}
+
+	var zero Deque
+	if err := zero.AppendLeft(1); err != nil {
+		t.Error(err)
+	} else if zero.String() != "[1]" {
+		t.Fatalf("Got %s, was expecting [1]", zero.String())
+	}
+}
+
+func TestGetSet(t *testing.T) {
+	d, _ := MakeDequeFromValues(1, 2, 3)
+	d.AppendLeft(0)
+
+	if value, err := d.Get(0); err != nil || value != 0 {
+		t.Fatalf("Got %v, was expecting 0", value)
+	} else if value, err := d.Get(-1); err != nil || value != 3 {
+		t.Fatalf("Got %v, was expecting 3", value)
+	} else if _, err := d.Get(4); err == nil {
+		t.Fatal("Expected index error")
+	}
+
+	if err := d.Set(-2, "two"); err != nil {
+		t.Error(err)
+	} else if d.String() != "[0 1 two 3]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	} else if err := d.Set(-5, 0); err == nil {
+		t.Fatal("Expected index error")
+	}
+
+	if ok, err := d.Contains("two"); err != nil || !ok {
+		t.Fatal("Expected deque to contain two")
+	} else if ok, err := d.Contains(2); err != nil || ok {
+		t.Fatal("Expected deque not to contain 2")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "[0 1 2 3 4]"},
+		{1, "[4 0 1 2 3]"},
+		{4, "[1 2 3 4 0]"},
+		{-1, "[1 2 3 4 0]"},
+		{-3, "[3 4 0 1 2]"},
+		{7, "[3 4 0 1 2]"},
+		{-10, "[0 1 2 3 4]"},
+	}
+	for _, test := range tests {
+		d, _ := MakeDequeFromValues(0, 1, 2, 3, 4)
+		d.Rotate(test.n)
+		if d.String() != test.expected {
+			t.Errorf("Rotate(%d): got %s, was expecting %s", test.n, d.String(), test.expected)
+		}
+	}
+
+	d, _ := MakeDequeFromValues(1, 2, 3, 4, 5, 6, 7, 8)
+	d.PopLeft()
+	d.Append(9)
+	d.Rotate(3)
+	if d.String() != "[7 8 9 2 3 4 5 6]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	empty, _ := MakeDeque()
+	empty.Rotate(3)
+	if empty.Length() != 0 {
+		t.Fatal("Expected deque to be empty")
+	}
+}
+
+func TestMaxLen(t *testing.T) {
+	if _, err := MakeDequeWithMaxLen(0); err == nil {
+		t.Fatal("Expected error for maximum length 0")
+	}
+
+	l, _ := list.MakeListFromValues(1, 2, 3, 4, 5)
+	d, err := MakeDequeWithMaxLen(3, l)
+	if err != nil {
+		t.Fatal(err)
+	} else if d.String() != "[3 4 5]" || d.MaxLen() != 3 {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if err := d.Append(6); err != nil {
+		t.Error(err)
+	} else if d.String() != "[4 5 6]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if err := d.AppendLeft(3); err != nil {
+		t.Error(err)
+	} else if d.String() != "[3 4 5]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if err := d.ExtendLeft(l); err != nil {
+		t.Error(err)
+	} else if d.String() != "[5 4 3]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if c, err := d.Copy(); err != nil {
+		t.Error(err)
+	} else if c.MaxLen() != 3 {
+		t.Fatalf("Got maximum length %d, was expecting 3", c.MaxLen())
+	} else if err := c.Append(0); err != nil {
+		t.Error(err)
+	} else if c.String() != "[4 3 0]" || d.String() != "[5 4 3]" {
+		t.Fatalf("Got %s and %s, which was unexpected", c.String(), d.String())
+	}
+
+	if err := d.Clear(); err != nil || d.Length() != 0 || d.MaxLen() != 3 {
+		t.Fatal("Expected empty deque keeping its maximum length")
+	}
+}
+
+func TestIterable(t *testing.T) {
+	d, _ := MakeDequeFromValues(2, 3)
+	d.AppendLeft(1)
+	if err := d.Extend(d); err != nil {
+		t.Error(err)
+	} else if d.String() != "[1 2 3 1 2 3]" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	l, _ := list.MakeList(d)
+	if l.String() != "[1 2 3 1 2 3]" {
+		t.Fatalf("Got %s, which was unexpected", l.String())
+	}
+	s, _ := set.MakeSet(d)
+	if s.Length() != 3 {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+	tup, _ := tuple.MakeTuple(d)
+	if tup.Length() != 6 {
+		t.Fatalf("Got %s, which was unexpected", tup.String())
+	}
+
+	count := 0
+	for range d.Iterate() {
+		count += 1
+	}
+	it := d.Iterator()
+	for it.Next() {
+		count += 1
+	}
+	if count != 12 {
+		t.Fatalf("Got %d values, was expecting 12", count)
+	}
+
+	other, _ := MakeDequeFromValues(1, 2, 3, 1, 2, 3)
+	if ok, err := d.Equals(other); err != nil || !ok {
+		t.Fatal("Expected deques to be equal")
+	} else if other.Rotate(1); d.(*Deque).EqualTo(other) {
+		t.Fatal("Expected rotated deque not to be equal")
+	}
+}
@@ -0,0 +1,58 @@
+package deque
+
+import "github.com/dynago/dg/iterable"
+
+// DequeInterface is the interface which defines whether a struct is a deque or not.
+type DequeInterface interface {
+	/* Return the number of elements in deque. */
+	Length() int
+	/* Return the next value in deque. */
+	Iterate() <-chan interface{}
+	/* Call the function on each value in deque, from left to right, until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the values in deque. */
+	Iterator() iterable.Iterator
+
+	/* Test for membership in the deque. */
+	Contains(interface{}) (bool, error)
+	/* Return true if the deque has all elements in common with the other deque, in the same order. */
+	Equals(DequeInterface) (bool, error)
+
+	/* Returns the value at index. Negative indexes count from the right. */
+	Get(int) (interface{}, error)
+	/* Sets the value at index. Negative indexes count from the right. */
+	Set(int, interface{}) error
+	/* Returns the rightmost value without removing it. */
+	Peek() (interface{}, error)
+	/* Returns the leftmost value without removing it. */
+	PeekLeft() (interface{}, error)
+
+	/* Appends element to the right of the deque. */
+	Append(interface{}) error
+	/* Appends element to the left of the deque. */
+	AppendLeft(interface{}) error
+	/* Appends the values in the iterable to the right of the deque. */
+	Extend(iterable.Iterable) error
+	/* Appends the values in the iterable to the left of the deque, one at a time, so they end up in reverse order. */
+	ExtendLeft(iterable.Iterable) error
+	/* Pop and return the rightmost element from the deque. */
+	Pop() (interface{}, error)
+	/* Pop and return the leftmost element from the deque. */
+	PopLeft() (interface{}, error)
+	/* Rotate the deque n steps to the right, or to the left if n is negative. */
+	Rotate(int)
+	/* Clear all elements from the deque. */
+	Clear() error
+
+	/* Return the maximum length of the deque, or 0 if it is unbounded. */
+	MaxLen() int
+
+	/* Creates a copy of the current DequeInterface. */
+	Copy() (DequeInterface, error)
+
+	/* Returns a string representation of the deque. */
+	String() string
+
+	/* Initializes the deque. */
+	Init()
+}
@@ -0,0 +1,21 @@
+package deque
+
+import "github.com/dynago/dg/internal/jsonvalue"
+
+/* MarshalJSON encodes the deque as a JSON array, from left to right. */
+func (d *Deque) MarshalJSON() ([]byte, error) {
+	return jsonvalue.EncodeArray(d.snapshot(), false)
+}
+
+/* UnmarshalJSON decodes a JSON array into the deque, keeping its maximum length. Nested arrays become lists and nested objects become dicts. */
+func (d *Deque) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, false)
+	if err != nil {
+		return err
+	}
+	d.fill(values)
+	return nil
+}
@@ -0,0 +1,27 @@
+package deque
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	d, _ := MakeDequeFromValues(2, "b")
+	d.AppendLeft(1.5)
+	if b, err := json.Marshal(d); err != nil {
+		t.Error(err)
+	} else if string(b) != `[1.5,2,"b"]` {
+		t.Errorf("Expected array, got %s", b)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	d, _ := MakeDequeWithMaxLen(2)
+	if err := json.Unmarshal([]byte(`[1, 2, 3]`), d); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if d.String() != "[2 3]" {
+		t.Errorf("Expected [2 3], got %v", d)
+	} else if err := json.Unmarshal([]byte(`{"a": 1}`), d); err == nil {
+		t.Error("Expected error for object")
+	}
+}
@@ -0,0 +1,194 @@
+package dict
+
+import (
+	"encoding/gob"
+
+	"github.com/dynago/dg/codec"
+	"github.com/dynago/dg/errs"
+)
+
+func init() {
+	build := func(elements []interface{}) (interface{}, error) {
+		keys, values, err := splitItems(elements)
+		if err != nil {
+			return nil, err
+		}
+		return MakeDictFromKeyValues(keys, values)
+	}
+	codec.RegisterContainer("dict", (*Dict)(nil), binaryElements, build)
+	codec.RegisterContainer("dict", (*SyncDict)(nil), binaryElements, build)
+	codec.RegisterContainer("dict", (*Concurrent)(nil), binaryElements, build)
+	codec.RegisterContainer("dict", (*DefaultDict)(nil), binaryElements, build)
+	codec.RegisterContainer("sorteddict", (*SortedDict)(nil), binaryElements, func(elements []interface{}) (interface{}, error) {
+		output, err := MakeSortedDict()
+		if err != nil {
+			return nil, err
+		}
+		return output, fill(output, elements)
+	})
+	gob.Register(new(Dict))
+	gob.Register(new(SyncDict))
+	gob.Register(new(Concurrent))
+	gob.Register(new(SortedDict))
+	gob.Register(new(DefaultDict))
+}
+
+/* MarshalBinary encodes the dict, preserving the type of every key and value. */
+func (d *Dict) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(d)
+}
+
+/* UnmarshalBinary decodes a dict encoded by MarshalBinary into the dict. */
+func (d *Dict) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(d, data)
+}
+
+/* GobEncode encodes the dict for encoding/gob. */
+func (d *Dict) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+/* GobDecode decodes the dict for encoding/gob. */
+func (d *Dict) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes the dict, preserving the type of every key and value. */
+func (d *SyncDict) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(d)
+}
+
+/* UnmarshalBinary decodes a dict encoded by MarshalBinary into the dict. */
+func (d *SyncDict) UnmarshalBinary(data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dict == nil {
+		d.dict = new(Dict)
+	}
+	return unmarshalBinary(d.dict, data)
+}
+
+/* GobEncode encodes the dict for encoding/gob. */
+func (d *SyncDict) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+/* GobDecode decodes the dict for encoding/gob. */
+func (d *SyncDict) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes a snapshot of the dict, preserving the type of every key and value. */
+func (d *Concurrent) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(d)
+}
+
+/* UnmarshalBinary decodes a dict encoded by MarshalBinary into the dict. */
+func (d *Concurrent) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(d, data)
+}
+
+/* GobEncode encodes the dict for encoding/gob. */
+func (d *Concurrent) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+/* GobDecode decodes the dict for encoding/gob. */
+func (d *Concurrent) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes the dict, preserving the type of every key and value. The comparison function is not encoded, so the dict decodes ordered by order.Compare unless it is decoded into a dict with its own comparison function. */
+func (d *SortedDict) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(d)
+}
+
+/* UnmarshalBinary decodes a dict encoded by MarshalBinary into the dict. */
+func (d *SortedDict) UnmarshalBinary(data []byte) error {
+	return unmarshalBinary(d, data)
+}
+
+/* GobEncode encodes the dict for encoding/gob. */
+func (d *SortedDict) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+/* GobDecode decodes the dict for encoding/gob. */
+func (d *SortedDict) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* UnmarshalBinary decodes a dict encoded by MarshalBinary into the dict, keeping its factory. */
+func (d *DefaultDict) UnmarshalBinary(data []byte) error {
+	if d.Dict == nil {
+		d.Dict = new(Dict)
+	}
+	return unmarshalBinary(d.Dict, data)
+}
+
+/* GobDecode decodes the dict for encoding/gob. The factory is not encoded, so a decoded dict returns nil for missing keys unless it was decoded into a dict with its own factory. */
+func (d *DefaultDict) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+/* binaryElements returns the keys and values of a snapshot of the dict to encode, alternating. */
+func binaryElements(v interface{}) ([]interface{}, error) {
+	d := v.(DictInterface)
+	switch d.(type) {
+	case *SyncDict, *Concurrent:
+		snapshot, err := d.Copy()
+		if err != nil {
+			return nil, err
+		}
+		d = snapshot
+	}
+	elements := make([]interface{}, 0, 2*d.Length())
+	var err error
+	d.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = d.Get(key); err != nil {
+			return false
+		}
+		elements = append(elements, key, value)
+		return true
+	})
+	return elements, err
+}
+
+/* splitItems splits alternating keys and values into keys and values. */
+func splitItems(elements []interface{}) ([]interface{}, []interface{}, error) {
+	if len(elements)%2 != 0 {
+		return nil, nil, &errs.LengthMismatchError{Length: len(elements) / 2, Expected: (len(elements) + 1) / 2, Of: "values"}
+	}
+	keys := make([]interface{}, 0, len(elements)/2)
+	values := make([]interface{}, 0, len(elements)/2)
+	for i := 0; i < len(elements); i += 2 {
+		keys = append(keys, elements[i])
+		values = append(values, elements[i+1])
+	}
+	return keys, values, nil
+}
+
+/* unmarshalBinary replaces the items of the dict with the decoded items. */
+func unmarshalBinary(d DictInterface, data []byte) error {
+	elements, err := codec.UnmarshalElements(data, "dict", "sorteddict")
+	if err != nil {
+		return err
+	}
+	return fill(d, elements)
+}
+
+/* fill replaces the items of the dict with alternating keys and values. */
+func fill(d DictInterface, elements []interface{}) error {
+	keys, values, err := splitItems(elements)
+	if err != nil {
+		return err
+	}
+	d.Init()
+	for i, key := range keys {
+		if err = d.Set(key, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
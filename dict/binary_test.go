@@ -0,0 +1,61 @@
+package dict
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+
+	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestBinary(t *testing.T) {
+	key, _ := tuple.MakeTupleFromValues(1, "a")
+	l, _ := list.MakeListFromValues(uint32(1), nil)
+	d, _ := MakeDictFromKeyValues([]interface{}{1, int64(1), 1.5, key}, []interface{}{"int", "int64", l, true})
+	c, _ := MakeConcurrent()
+	c.Combine(d)
+	for _, m := range []encoding.BinaryMarshaler{d.(*Dict), Synchronized(d).(*SyncDict), c} {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, decoded := range []DictInterface{new(Dict), new(SyncDict), new(Concurrent)} {
+			if err = decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+				t.Error(err)
+			} else if equals, _ := decoded.Equals(d); !equals {
+				t.Errorf("Expected %v, got %v", d, decoded)
+			}
+		}
+	}
+}
+
+type gobMessage struct {
+	Items DictInterface
+	Any   interface{}
+}
+
+func TestGob(t *testing.T) {
+	inner, _ := list.MakeListFromValues(int16(1), "a")
+	d, _ := MakeDictFromKeyValues([]interface{}{1, "b"}, []interface{}{inner, 2.5})
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(gobMessage{d, Synchronized(d)}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded gobMessage
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equals, _ := d.Equals(decoded.Items); !equals {
+		t.Errorf("Expected %v, got %v", d, decoded.Items)
+	} else if s, ok := decoded.Any.(*SyncDict); !ok {
+		t.Errorf("Expected synchronized dict, got %T", decoded.Any)
+	} else if equals, _ := s.Equals(d); !equals {
+		t.Errorf("Expected %v, got %v", d, s)
+	} else if v, _ := decoded.Items.Get(1); v == nil {
+		t.Error("Expected nested list")
+	} else if e, _ := v.(list.ListInterface).Get(0); e != int16(1) {
+		t.Errorf("Expected int16 element, got %T", e)
+	}
+}
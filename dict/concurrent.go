@@ -0,0 +1,433 @@
+package dict
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/tuple"
+)
+
+// DefaultShards is the number of shards used by MakeConcurrent.
+const DefaultShards = 32
+
+// shard is one independently locked segment of a concurrent dict.
+type shard struct {
+	mu   sync.RWMutex
+	dict *Dict
+}
+
+/* load returns the value at given key and whether the key is present. The shard must be locked. */
+func (s *shard) load(hash string, key interface{}) (interface{}, bool) {
+	if i := s.dict.find(hash, key); i >= 0 {
+		return s.dict.buckets[hash][i].value, true
+	}
+	return nil, false
+}
+
+// Concurrent is a dict which is safe for concurrent use. Keys are spread by hash across shards which are locked independently, so writers to different shards do not block each other. Operations spanning the whole dict, such as Length or iteration, see each shard consistently but not the dict as a whole.
+type Concurrent struct {
+	hasher hasher.Hasher
+	shards []*shard
+}
+
+/* Length returns the number of elements in dict. */
+func (d *Concurrent) Length() int {
+	length := 0
+	for _, s := range d.shards {
+		s.mu.RLock()
+		length += s.dict.Length()
+		s.mu.RUnlock()
+	}
+	return length
+}
+
+/* Iterate returns the next key in a snapshot of the dict. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (d *Concurrent) Iterate() <-chan interface{} {
+	return iterable.Channel(d)
+}
+
+/* ForEach calls the function on each key in a snapshot of the dict until it returns false. */
+func (d *Concurrent) ForEach(fn func(interface{}) bool) {
+	for _, key := range d.keys() {
+		if !fn(key) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the keys in dict. */
+func (d *Concurrent) Iterator() iterable.Iterator {
+	return iterable.FromSlice(d.keys())
+}
+
+/* Remove removes element from the dict. */
+func (d *Concurrent) Remove(key interface{}) error {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.dict.find(hash, key); i >= 0 {
+		s.dict.delete(hash, i)
+	}
+	return nil
+}
+
+/* Get returns the value with given key. */
+func (d *Concurrent) Get(key interface{}) (interface{}, error) {
+	value, _, err := d.load(key)
+	return value, err
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. */
+func (d *Concurrent) Lookup(key interface{}) (interface{}, bool, error) {
+	return d.load(key)
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. */
+func (d *Concurrent) GetOr(key interface{}, def interface{}) (interface{}, error) {
+	value, loaded, err := d.load(key)
+	if err != nil || !loaded {
+		return def, err
+	}
+	return value, nil
+}
+
+/* Set sets the value at given key to given value. */
+func (d *Concurrent) Set(key interface{}, value interface{}) error {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dict.store(hash, s.dict.find(hash, key), key, value)
+	return nil
+}
+
+/* Compute atomically replaces the value at given key with the result of the function, which is passed the current value and whether the key is present. If the function returns false the key is removed instead. Returns the new value. The function runs without holding a lock, so it may read or write the dict; the result is only stored if the key is unchanged when it returns, otherwise the function is called again with the new value. */
+func (d *Concurrent) Compute(key interface{}, fn func(value interface{}, loaded bool) (interface{}, bool)) (interface{}, error) {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		s.mu.RLock()
+		current, loaded := s.load(hash, key)
+		s.mu.RUnlock()
+		value, keep := fn(current, loaded)
+		s.mu.Lock()
+		i := s.dict.find(hash, key)
+		if (i >= 0) != loaded || (loaded && !equality.Equal(s.dict.buckets[hash][i].value, current)) {
+			s.mu.Unlock()
+			continue
+		}
+		if !keep {
+			if i >= 0 {
+				s.dict.delete(hash, i)
+			}
+			s.mu.Unlock()
+			return nil, nil
+		}
+		s.dict.store(hash, i, key, value)
+		s.mu.Unlock()
+		return value, nil
+	}
+}
+
+/* LoadOrStore returns the value at given key if present. Otherwise it sets the key to given value and returns that value. The boolean is true if the value was loaded rather than stored. */
+func (d *Concurrent) LoadOrStore(key interface{}, value interface{}) (interface{}, bool, error) {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return nil, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.dict.find(hash, key); i >= 0 {
+		return s.dict.buckets[hash][i].value, true, nil
+	}
+	s.dict.store(hash, -1, key, value)
+	return value, false, nil
+}
+
+/* CompareAndSwap sets the value at given key to new if the key is present and its value is equal to old. Returns true if the value was swapped. */
+func (d *Concurrent) CompareAndSwap(key interface{}, old interface{}, new interface{}) (bool, error) {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.dict.find(hash, key)
+	if i < 0 || !equality.Equal(s.dict.buckets[hash][i].value, old) {
+		return false, nil
+	}
+	s.dict.buckets[hash][i].value = new
+	return true, nil
+}
+
+/* Combine updates the dict, adding elements from the other dict. Old values are replaced with new. */
+func (d *Concurrent) Combine(other DictInterface) error {
+	var err error
+	other.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = other.Get(key); err != nil {
+			return false
+		}
+		err = d.Set(key, value)
+		return err == nil
+	})
+	return err
+}
+
+/* PopKey pops and returns an arbitrary key from the dict, or ErrEmpty if it is empty. */
+func (d *Concurrent) PopKey() (interface{}, error) {
+	key, _, err := d.Pop()
+	return key, err
+}
+
+/* PopValue pops and returns an arbitrary value from the dict, or ErrEmpty if it is empty. */
+func (d *Concurrent) PopValue() (interface{}, error) {
+	_, value, err := d.Pop()
+	return value, err
+}
+
+/* Pop pops and returns an arbitrary item from the dict, or ErrEmpty if it is empty. */
+func (d *Concurrent) Pop() (interface{}, interface{}, error) {
+	for _, s := range d.shards {
+		s.mu.Lock()
+		key, value, err := s.dict.Pop()
+		s.mu.Unlock()
+		if !errors.Is(err, ErrEmpty) {
+			return key, value, err
+		}
+	}
+	return nil, nil, &errs.EmptyError{Op: "pop from", Structure: "dict"}
+}
+
+/* Clear clears all elements from the dict. */
+func (d *Concurrent) Clear() error {
+	for _, s := range d.shards {
+		s.mu.Lock()
+		s.dict.Init()
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+/* Contains tests for membership in the dict. */
+func (d *Concurrent) Contains(key interface{}) (bool, error) {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dict.find(hash, key) >= 0, nil
+}
+
+/* Equals returns true if the dict has all elements in common with the other dict. */
+func (d *Concurrent) Equals(other DictInterface) (bool, error) {
+	if d.Length() != other.Length() {
+		return false, nil
+	}
+	equals := true
+	var err error
+	other.ForEach(func(ok interface{}) bool {
+		var ov, v interface{}
+		if ov, err = other.Get(ok); err != nil {
+			return false
+		}
+		var loaded bool
+		if v, loaded, err = d.load(ok); err != nil {
+			return false
+		}
+		equals = loaded && equality.Equal(v, ov)
+		return equals
+	})
+	if err != nil {
+		return false, err
+	}
+	return equals, nil
+}
+
+/* HashKey returns the bytes which identify the dict, so it can be used as a dict key or set element. The order of the keys does not change the key. The dict must not be changed while it is a key. */
+func (d *Concurrent) HashKey() ([]byte, error) {
+	return hashKey(d)
+}
+
+/* EqualTo returns true if the other value is a dict with equal keys and values. */
+func (d *Concurrent) EqualTo(other interface{}) bool {
+	o, ok := other.(DictInterface)
+	if !ok {
+		return false
+	}
+	equals, err := d.Equals(o)
+	return err == nil && equals
+}
+
+/* Keys returns a tuple of keys. */
+func (d *Concurrent) Keys() (tuple.TupleInterface, error) {
+	return tuple.MakeTupleFromValues(d.keys()...)
+}
+
+/* Values returns a tuple of values. */
+func (d *Concurrent) Values() (tuple.TupleInterface, error) {
+	values := make([]interface{}, 0)
+	d.forEachEntry(func(e *entry) {
+		values = append(values, e.value)
+	})
+	return tuple.MakeTupleFromValues(values...)
+}
+
+/* Items returns a tuple of key/value pairs. */
+func (d *Concurrent) Items() (tuple.TupleInterface, error) {
+	items := make([]interface{}, 0)
+	var err error
+	d.forEachEntry(func(e *entry) {
+		if err != nil {
+			return
+		}
+		var item tuple.TupleInterface
+		item, err = tuple.MakeTupleFromValues(e.key, e.value)
+		items = append(items, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tuple.MakeTupleFromValues(items...)
+}
+
+/* Copy creates a copy of the current DictInterface */
+func (d *Concurrent) Copy() (DictInterface, error) {
+	output := &Concurrent{hasher: d.hasher, shards: make([]*shard, len(d.shards))}
+	for i, s := range d.shards {
+		s.mu.RLock()
+		c, err := s.dict.Copy()
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		output.shards[i] = &shard{dict: c.(*Dict)}
+	}
+	return output, nil
+}
+
+/* String returns a string representation of the dict. */
+func (d *Concurrent) String() string {
+	output := "{"
+	d.forEachEntry(func(e *entry) {
+		output += fmt.Sprintf("(%v %v) ", e.key, e.value)
+	})
+	output = strings.Trim(output, " ") + "}"
+	return output
+}
+
+/* Hasher returns the Hasher used to hash keys in the dict. */
+func (d *Concurrent) Hasher() hasher.Hasher {
+	return d.hasher
+}
+
+/* Init initializes the dict. */
+func (d *Concurrent) Init() {
+	if d.hasher == nil {
+		d.hasher = hasher.Default()
+	}
+	if len(d.shards) == 0 {
+		d.shards = make([]*shard, DefaultShards)
+	}
+	for i, s := range d.shards {
+		if s == nil {
+			d.shards[i] = &shard{dict: &Dict{hasher: d.hasher}}
+			s = d.shards[i]
+		}
+		s.mu.Lock()
+		s.dict.Init()
+		s.mu.Unlock()
+	}
+}
+
+/* shardFor returns the shard holding the key and the hash of the key. */
+func (d *Concurrent) shardFor(key interface{}) (*shard, string, error) {
+	hash, err := hasher.Hash(d.hasher, key)
+	if err != nil {
+		return nil, "", err
+	}
+	// FNV-1a over the hash spreads keys evenly whatever the Hasher's output looks like.
+	h := uint32(2166136261)
+	for i := 0; i < len(hash); i++ {
+		h ^= uint32(hash[i])
+		h *= 16777619
+	}
+	return d.shards[h%uint32(len(d.shards))], hash, nil
+}
+
+/* load returns the value with given key and whether the key is present. */
+func (d *Concurrent) load(key interface{}) (interface{}, bool, error) {
+	s, hash, err := d.shardFor(key)
+	if err != nil {
+		return nil, false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.dict.find(hash, key)
+	if i < 0 {
+		return nil, false, nil
+	}
+	return s.dict.buckets[hash][i].value, true, nil
+}
+
+/* forEachEntry calls the function on each entry in the dict, holding the lock of one shard at a time. */
+func (d *Concurrent) forEachEntry(fn func(*entry)) {
+	for _, s := range d.shards {
+		s.mu.RLock()
+		for e := s.dict.first; e != nil; e = e.next {
+			fn(e)
+		}
+		s.mu.RUnlock()
+	}
+}
+
+/* keys returns a snapshot of the keys in the dict. */
+func (d *Concurrent) keys() []interface{} {
+	keys := make([]interface{}, 0)
+	d.forEachEntry(func(e *entry) {
+		keys = append(keys, e.key)
+	})
+	return keys
+}
+
+/* MakeConcurrent initializes a new concurrent dict object with DefaultShards shards using an Iterable. Every even-indexed element is a key and odd-indexed element is a value. */
+func MakeConcurrent(it ...iterable.Iterable) (*Concurrent, error) {
+	return MakeConcurrentWithShards(DefaultShards, hasher.Default(), it...)
+}
+
+/* MakeConcurrentWithShards initializes a new concurrent dict object which spreads keys across the given number of shards and hashes them using the given Hasher. */
+func MakeConcurrentWithShards(shards int, h hasher.Hasher, it ...iterable.Iterable) (*Concurrent, error) {
+	if shards < 1 {
+		return nil, fmt.Errorf("Number of shards must be at least 1")
+	}
+	output := &Concurrent{hasher: h, shards: make([]*shard, shards)}
+	output.Init()
+	if len(it) > 0 {
+		i := 0
+		var key interface{}
+		iterable.ForEach(it[0], func(v interface{}) bool {
+			if i%2 == 0 {
+				key = v
+			} else {
+				output.Set(key, v)
+			}
+			i += 1
+			return true
+		})
+	}
+	return output, nil
+}
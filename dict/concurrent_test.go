@@ -0,0 +1,197 @@
+package dict
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dynago/dg/hasher"
+)
+
+func TestMakeConcurrent(t *testing.T) {
+	var d DictInterface
+	d, err := MakeConcurrent()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.Set(1, "a")
+	d.Set("b", 2)
+	if d.Length() != 2 {
+		t.Errorf("Expected 2 elements, got %d", d.Length())
+	} else if v, _ := d.Get(1); v != "a" {
+		t.Errorf("Expected value a, got %v", v)
+	} else if ok, _ := d.Contains("b"); !ok {
+		t.Error("Expected dict to contain b")
+	} else if _, err := MakeConcurrentWithShards(0, nil); err == nil {
+		t.Error("Expected error for zero shards")
+	}
+	other, _ := MakeDictFromKeyValues([]interface{}{1, "b"}, []interface{}{"a", 2})
+	if equals, err := d.Equals(other); err != nil || !equals {
+		t.Errorf("Expected %v to equal %v", d, other)
+	}
+	c, err := d.Copy()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d.Remove(1)
+	if d.Length() != 1 || c.Length() != 2 {
+		t.Errorf("Expected copy to be independent, got %v and %v", d, c)
+	}
+	d.Clear()
+	if d.Length() != 0 {
+		t.Errorf("Expected empty dict, got %v", d)
+	} else if k, v, err := d.Pop(); k != nil || v != nil || !errors.Is(err, ErrEmpty) {
+		t.Errorf("Expected ErrEmpty from pop from empty dict, got %v", err)
+	}
+}
+
+func TestConcurrentCompute(t *testing.T) {
+	d, _ := MakeConcurrent()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.Compute(j%10, func(value interface{}, loaded bool) (interface{}, bool) {
+					if !loaded {
+						return 1, true
+					}
+					return value.(int) + 1, true
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	for j := 0; j < 10; j++ {
+		if v, _ := d.Get(j); v != 80 {
+			t.Errorf("Expected count 80 for %d, got %v", j, v)
+		}
+	}
+	v, err := d.Compute(0, func(value interface{}, loaded bool) (interface{}, bool) {
+		return nil, false
+	})
+	if v != nil || err != nil {
+		t.Errorf("Expected nil from removing compute, got %v, %v", v, err)
+	} else if ok, _ := d.Contains(0); ok {
+		t.Error("Expected compute to remove key")
+	}
+}
+
+func TestConcurrentComputeReentrant(t *testing.T) {
+	d, _ := MakeConcurrentWithShards(1, hasher.Default())
+	d.Set("a", 1)
+	calls := 0
+	done := make(chan interface{})
+	go func() {
+		v, _ := d.Compute("a", func(value interface{}, loaded bool) (interface{}, bool) {
+			calls += 1
+			if calls == 1 {
+				d.Set("a", 10)
+			}
+			d.Set("b", value)
+			return value.(int) + 1, true
+		})
+		done <- v
+	}()
+	select {
+	case v := <-done:
+		if v != 11 || calls != 2 {
+			t.Errorf("Expected 11 after computing again on the changed value, got %v after %d calls", v, calls)
+		} else if b, _ := d.Get("b"); b != 10 {
+			t.Errorf("Expected the function to write to the dict, got %v", b)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Compute not to deadlock when the function uses the dict")
+	}
+}
+
+func TestConcurrentLoadOrStore(t *testing.T) {
+	d, _ := MakeConcurrent()
+	if v, loaded, err := d.LoadOrStore("a", 1); v != 1 || loaded || err != nil {
+		t.Errorf("Expected store of 1, got %v, %v, %v", v, loaded, err)
+	} else if v, loaded, err := d.LoadOrStore("a", 2); v != 1 || !loaded || err != nil {
+		t.Errorf("Expected load of 1, got %v, %v, %v", v, loaded, err)
+	}
+}
+
+func TestConcurrentCompareAndSwap(t *testing.T) {
+	d, _ := MakeConcurrent()
+	d.Set("a", []int{1})
+	if swapped, err := d.CompareAndSwap("a", []int{2}, 3); swapped || err != nil {
+		t.Error("Expected no swap for unequal old value")
+	} else if swapped, err := d.CompareAndSwap("a", []int{1}, 3); !swapped || err != nil {
+		t.Error("Expected swap for equal old value")
+	} else if v, _ := d.Get("a"); v != 3 {
+		t.Errorf("Expected 3, got %v", v)
+	} else if swapped, _ := d.CompareAndSwap("b", nil, 1); swapped {
+		t.Error("Expected no swap for missing key")
+	}
+}
+
+func TestConcurrentIterate(t *testing.T) {
+	d, _ := MakeConcurrentWithShards(4, nil)
+	for i := 0; i < 100; i++ {
+		d.Set(i, i)
+	}
+	count := 0
+	d.ForEach(func(key interface{}) bool {
+		count++
+		d.Remove(key)
+		return true
+	})
+	if count != 100 || d.Length() != 0 {
+		t.Errorf("Expected to visit and remove 100 keys, visited %d, left %v", count, d)
+	}
+}
+
+func benchmarkSet(b *testing.B, d DictInterface) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			d.Set(fmt.Sprint(i%1000), i)
+			i++
+		}
+	})
+}
+
+func benchmarkMixed(b *testing.B, d DictInterface) {
+	for i := 0; i < 1000; i++ {
+		d.Set(fmt.Sprint(i), i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			key := fmt.Sprint(i % 1000)
+			if i%4 == 0 {
+				d.Set(key, i)
+			} else {
+				d.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentSet(b *testing.B) {
+	d, _ := MakeConcurrent()
+	benchmarkSet(b, d)
+}
+
+func BenchmarkSynchronizedSet(b *testing.B) {
+	d, _ := MakeDict()
+	benchmarkSet(b, Synchronized(d))
+}
+
+func BenchmarkConcurrentMixed(b *testing.B) {
+	d, _ := MakeConcurrent()
+	benchmarkMixed(b, d)
+}
+
+func BenchmarkSynchronizedMixed(b *testing.B) {
+	d, _ := MakeDict()
+	benchmarkMixed(b, Synchronized(d))
+}
@@ -0,0 +1,69 @@
+package dict
+
+import (
+	"github.com/dynago/dg/iterable"
+)
+
+// DefaultDict is a dict which calls a factory to make the value of a missing key. Get on a missing key sets the key to the result of the factory and returns it, so values such as lists or counts can be used without checking for the key first. Every other method behaves as for Dict.
+type DefaultDict struct {
+	*Dict
+	factory func() interface{}
+}
+
+/* Get returns the value with given key. A missing key is set to the result of the factory, which is returned. If the dict has no factory a missing key returns nil, as for Dict. */
+func (d *DefaultDict) Get(key interface{}) (interface{}, error) {
+	hash, i, err := d.lookup(key)
+	if err != nil {
+		return nil, err
+	}
+	if i >= 0 {
+		return d.buckets[hash][i].value, nil
+	}
+	if d.factory == nil {
+		return nil, nil
+	}
+	value := d.factory()
+	d.store(hash, i, key, value)
+	return value, nil
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. Unlike Get it does not call the factory. */
+func (d *DefaultDict) Lookup(key interface{}) (interface{}, bool, error) {
+	return d.Dict.Lookup(key)
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. Unlike Get it does not call the factory. */
+func (d *DefaultDict) GetOr(key interface{}, def interface{}) (interface{}, error) {
+	return d.Dict.GetOr(key, def)
+}
+
+/* Copy creates a copy of the current DictInterface with the same factory. Values are not copied, so a copied dict shares values such as lists with the dict. */
+func (d *DefaultDict) Copy() (DictInterface, error) {
+	c, err := d.Dict.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &DefaultDict{Dict: c.(*Dict), factory: d.factory}, nil
+}
+
+/* Factory returns the function which makes the value of a missing key. */
+func (d *DefaultDict) Factory() func() interface{} {
+	return d.factory
+}
+
+/* Init initializes the dict, keeping its factory. */
+func (d *DefaultDict) Init() {
+	if d.Dict == nil {
+		d.Dict = new(Dict)
+	}
+	d.Dict.Init()
+}
+
+/* MakeDefaultDict initializes a new dict object which calls the factory to make the value of a missing key, using an Iterable. Every even-indexed element is a key and odd-indexed element is a value. */
+func MakeDefaultDict(factory func() interface{}, it ...iterable.Iterable) (*DefaultDict, error) {
+	d, err := MakeDict(it...)
+	if err != nil {
+		return nil, err
+	}
+	return &DefaultDict{Dict: d.(*Dict), factory: factory}, nil
+}
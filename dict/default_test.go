@@ -0,0 +1,122 @@
+package dict
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/dynago/dg/codec"
+	"github.com/dynago/dg/list"
+)
+
+func TestDefaultDict(t *testing.T) {
+	d, err := MakeDefaultDict(func() interface{} {
+		l, _ := list.MakeList()
+		return l
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edges := [][2]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}
+	for _, edge := range edges {
+		v, err := d.Get(edge[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		v.(list.ListInterface).Append(edge[1])
+	}
+	if d.String() != "{(a [b c]) (b [c])}" {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if ok, err := d.Contains("z"); err != nil || ok {
+		t.Fatal("Expected Contains not to add the key")
+	} else if v, err := d.Get("z"); err != nil || v.(list.ListInterface).Length() != 0 {
+		t.Fatalf("Got %v, was expecting an empty list", v)
+	} else if d.Length() != 3 {
+		t.Fatalf("Got length %d, was expecting 3", d.Length())
+	}
+
+	if err := d.Set("n", nil); err != nil {
+		t.Error(err)
+	} else if v, err := d.Get("n"); err != nil || v != nil {
+		t.Fatalf("Got %v, was expecting the stored nil", v)
+	}
+
+	if _, err := d.Get(nil); err == nil {
+		t.Fatal("Expected error for unhashable key")
+	}
+
+	c, err := d.Copy()
+	if err != nil {
+		t.Error(err)
+	} else if _, ok := c.(*DefaultDict); !ok {
+		t.Fatalf("Got %T, was expecting a default dict", c)
+	} else if v, _ := c.Get("new"); v == nil {
+		t.Fatal("Expected copy to keep the factory")
+	} else if ok, _ := d.Contains("new"); ok {
+		t.Fatal("Expected dict to be unchanged by its copy")
+	}
+
+	if err := d.MoveToEnd("a"); err != nil {
+		t.Error(err)
+	} else if key, _, _ := d.PopLast(); key != "a" {
+		t.Fatalf("Got %v, was expecting a", key)
+	}
+
+	counts, _ := MakeDefaultDict(func() interface{} { return 0 })
+	for _, word := range []string{"x", "y", "x"} {
+		v, _ := counts.Get(word)
+		counts.Set(word, v.(int)+1)
+	}
+	if counts.String() != "{(x 2) (y 1)}" {
+		t.Fatalf("Got %s, which was unexpected", counts.String())
+	}
+}
+
+func TestDefaultDictEncoding(t *testing.T) {
+	d, _ := MakeDefaultDict(func() interface{} { return 0 })
+	d.Set("a", 1)
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Error(err)
+	} else if string(b) != `{"a":1}` {
+		t.Fatalf("Got %s, which was unexpected", b)
+	}
+	decoded := new(DefaultDict)
+	if err := json.Unmarshal([]byte(`{"b":2}`), decoded); err != nil {
+		t.Error(err)
+	} else if v, err := decoded.Get("missing"); err != nil || v != nil {
+		t.Fatalf("Got %v, was expecting nil without a factory", v)
+	}
+
+	b, err = d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := codec.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if ok := d.EqualTo(v); !ok {
+		t.Fatalf("Got %v, was expecting %v", v, d)
+	}
+	into, _ := MakeDefaultDict(func() interface{} { return "default" })
+	if err := into.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if v, _ := into.Get("z"); v != "default" || into.Length() != 2 {
+		t.Fatalf("Got %v, was expecting the factory to be kept", into)
+	}
+
+	var buf bytes.Buffer
+	var in, out DictInterface
+	in = d
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	} else if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	} else if _, ok := out.(*DefaultDict); !ok || !d.EqualTo(out) {
+		t.Fatalf("Got %v, was expecting %v", out, d)
+	}
+}
@@ -1,127 +1,170 @@
-// Package dict implements dictionaries, which are collections of unordered key/value pairs.
+// Package dict implements dictionaries, which are collections of key/value pairs kept in insertion order.
 package dict
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
-	"github.com/dynago/dg/internal/helpers"
-	"github.com/dynago/dg/internal/iterable"
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
+	"github.com/dynago/dg/iterable"
 	"github.com/dynago/dg/list"
 	"github.com/dynago/dg/tuple"
 )
 
-// Dict is a dynamic dictionary structure.
+// entry is a key/value pair stored in a dict.
+type entry struct {
+	key     interface{}
+	value   interface{}
+	prev    *entry // entry inserted before this one
+	next    *entry // entry inserted after this one
+	removed bool   // set once the entry is deleted, so ForEach skips it
+}
+
+// Dict is a dynamic dictionary structure which keeps its keys in insertion order.
 type Dict struct {
-	keys   map[string]interface{} // hash of key to key
-	values map[string]interface{} // hash of key to value
+	hasher  hasher.Hasher       // hashes keys
+	buckets map[string][]*entry // hash of key to entries with that hash
+	first   *entry              // least recently inserted entry
+	last    *entry              // most recently inserted entry
+	length  int
 }
 
 /* Length returns the number of elements in dict. */
 func (d *Dict) Length() int {
-	return len(d.keys)
+	return d.length
 }
 
-/* Iterate returns the next key in dict. */
+/* Iterate returns the next key in dict. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
 func (d *Dict) Iterate() <-chan interface{} {
-	c := make(chan interface{})
-	go func() {
-		for _, v := range d.keys {
-			c <- v
+	return iterable.Channel(d)
+}
+
+/* ForEach calls the function on each key in dict, in insertion order, until it returns false. Keys removed by the function are not visited. */
+func (d *Dict) ForEach(fn func(interface{}) bool) {
+	for e := d.first; e != nil; {
+		if !fn(e.key) {
+			return
 		}
-		close(c)
-	}()
-	return c
+		e = e.next
+		for e != nil && e.removed {
+			e = e.next
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the keys in dict, in insertion order. */
+func (d *Dict) Iterator() iterable.Iterator {
+	keys := make([]interface{}, 0, d.length)
+	for e := d.first; e != nil; e = e.next {
+		keys = append(keys, e.key)
+	}
+	return iterable.FromSlice(keys)
 }
 
 /* Remove removes element from the dict. */
 func (d *Dict) Remove(key interface{}) error {
-	hash, err := helpers.GetSHA(key)
-	if err != nil {
+	hash, i, err := d.lookup(key)
+	if err != nil || i < 0 {
 		return err
 	}
-	delete(d.keys, hash)
-	delete(d.values, hash)
+	d.delete(hash, i)
 	return nil
 }
 
-/* Get returns the value with given key. */
+/* Get returns the value with given key, or nil if the key is not in the dict. Use Lookup to tell a missing key from a nil value. */
 func (d *Dict) Get(key interface{}) (interface{}, error) {
-	hash, err := helpers.GetSHA(key)
-	if err != nil {
+	hash, i, err := d.lookup(key)
+	if err != nil || i < 0 {
 		return nil, err
 	}
-	value, ok := d.values[hash]
-	if !ok {
-		return nil, nil
+	return d.buckets[hash][i].value, nil
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. */
+func (d *Dict) Lookup(key interface{}) (interface{}, bool, error) {
+	hash, i, err := d.lookup(key)
+	if err != nil || i < 0 {
+		return nil, false, err
+	}
+	return d.buckets[hash][i].value, true, nil
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. */
+func (d *Dict) GetOr(key interface{}, def interface{}) (interface{}, error) {
+	value, found, err := d.Lookup(key)
+	if err != nil || !found {
+		return def, err
 	}
 	return value, nil
 }
 
-/* Set sets the value at given key to given value. */
+/* Set sets the value at given key to given value. A new key is added at the end of the dict, and an existing key keeps its place. */
 func (d *Dict) Set(key interface{}, value interface{}) error {
-	hash, err := helpers.GetSHA(key)
+	hash, i, err := d.lookup(key)
 	if err != nil {
 		return err
 	}
-	d.keys[hash] = key
-	d.values[hash] = value
+	d.store(hash, i, key, value)
 	return nil
 }
 
 /* Combine updates the dict, adding elements from the other dict. Old values are replaced with new. */
 func (d *Dict) Combine(other DictInterface) error {
-	for key := range other.Iterate() {
-		value, err := other.Get(key)
-		if err != nil {
-			return err
-		}
-		if err = d.Set(key, value); err != nil {
-			return err
+	var err error
+	other.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = other.Get(key); err != nil {
+			return false
 		}
-	}
-	return nil
+		err = d.Set(key, value)
+		return err == nil
+	})
+	return err
 }
 
-/* PopKey pops and returns an arbitrary key from the dict. */
+/* PopKey pops and returns the most recently inserted key from the dict, or ErrEmpty if it is empty. */
 func (d *Dict) PopKey() (interface{}, error) {
-	for key := range d.Iterate() {
-		if err := d.Remove(key); err != nil {
-			return nil, err
-		}
-		return key, nil
-	}
-	return nil, nil
+	key, _, err := d.Pop()
+	return key, err
 }
 
-/* PopValue pops and returns an arbitrary value from the dict. */
+/* PopValue pops and returns the most recently inserted value from the dict, or ErrEmpty if it is empty. */
 func (d *Dict) PopValue() (interface{}, error) {
-	for key := range d.Iterate() {
-		value, err := d.Get(key)
-		if err != nil {
-			return nil, err
-		}
-		if err = d.Remove(key); err != nil {
-			return nil, err
-		}
-		return value, nil
-	}
-	return nil, nil
+	_, value, err := d.Pop()
+	return value, err
 }
 
-/* Pop pops and returns an arbitrary item from the dict. */
+/* Pop pops and returns the most recently inserted item from the dict, or ErrEmpty if it is empty. */
 func (d *Dict) Pop() (interface{}, interface{}, error) {
-	for key := range d.Iterate() {
-		value, err := d.Get(key)
-		if err != nil {
-			return nil, nil, err
-		}
-		if err = d.Remove(key); err != nil {
-			return nil, nil, err
-		}
-		return key, value, nil
+	return d.PopLast()
+}
+
+/* PopFirst pops and returns the least recently inserted item from the dict, or ErrEmpty if it is empty. */
+func (d *Dict) PopFirst() (interface{}, interface{}, error) {
+	return d.popEntry(d.first)
+}
+
+/* PopLast pops and returns the most recently inserted item from the dict, or ErrEmpty if it is empty. */
+func (d *Dict) PopLast() (interface{}, interface{}, error) {
+	return d.popEntry(d.last)
+}
+
+/* MoveToEnd moves the key to the end of the dict, as if it had just been inserted. It returns ErrKeyNotFound if the key is not in the dict. */
+func (d *Dict) MoveToEnd(key interface{}) error {
+	hash, i, err := d.lookup(key)
+	if err != nil {
+		return err
 	}
-	return nil, nil, nil
+	if i < 0 {
+		return &errs.KeyError{Key: key, Structure: "dict"}
+	}
+	e := d.buckets[hash][i]
+	d.unlink(e)
+	d.link(e)
+	return nil
 }
 
 /* Clear clears all elements from the dict. */
@@ -132,12 +175,8 @@ func (d *Dict) Clear() error {
 
 /* Contains tests for membership in the dict. */
 func (d *Dict) Contains(key interface{}) (bool, error) {
-	hash, err := helpers.GetSHA(key)
-	if err != nil {
-		return false, err
-	}
-	_, ok := d.keys[hash]
-	return ok, nil
+	_, i, err := d.lookup(key)
+	return i >= 0, err
 }
 
 /* Equals returns true if the dict has all elements in common with the other dict. */
@@ -145,27 +184,47 @@ func (d *Dict) Equals(other DictInterface) (bool, error) {
 	if d.Length() != other.Length() {
 		return false, nil
 	}
-	for ok := range other.Iterate() {
-		ov, err1 := other.Get(ok)
-		if err1 != nil {
-			return false, err1
-		}
-		dv, err2 := d.Get(ok)
-		if err2 != nil {
-			return false, err2
+	equals := true
+	var err error
+	other.ForEach(func(ok interface{}) bool {
+		var ov interface{}
+		if ov, err = other.Get(ok); err != nil {
+			return false
 		}
-		if dv != ov {
-			return false, nil
+		var hash string
+		var i int
+		if hash, i, err = d.lookup(ok); err != nil {
+			return false
 		}
+		equals = i >= 0 && equality.Equal(d.buckets[hash][i].value, ov)
+		return equals
+	})
+	if err != nil {
+		return false, err
 	}
-	return true, nil
+	return equals, nil
 }
 
-/* Keys returns a tuple of keys. */
+/* HashKey returns the bytes which identify the dict, so it can be used as a dict key or set element. The order of the keys does not change the key. The dict must not be changed while it is a key. */
+func (d *Dict) HashKey() ([]byte, error) {
+	return hashKey(d)
+}
+
+/* EqualTo returns true if the other value is a dict with equal keys and values. */
+func (d *Dict) EqualTo(other interface{}) bool {
+	o, ok := other.(DictInterface)
+	if !ok {
+		return false
+	}
+	equals, err := d.Equals(o)
+	return err == nil && equals
+}
+
+/* Keys returns a tuple of keys in insertion order. */
 func (d *Dict) Keys() (tuple.TupleInterface, error) {
-	keys := make([]interface{}, 0)
-	for _, key := range d.keys {
-		keys = append(keys, key)
+	keys := make([]interface{}, 0, d.length)
+	for e := d.first; e != nil; e = e.next {
+		keys = append(keys, e.key)
 	}
 	tup, err := tuple.MakeTupleFromValues(keys...)
 	if err != nil {
@@ -174,11 +233,11 @@ func (d *Dict) Keys() (tuple.TupleInterface, error) {
 	return tup, nil
 }
 
-/* Values returns a tuple of values. */
+/* Values returns a tuple of values in insertion order of their keys. */
 func (d *Dict) Values() (tuple.TupleInterface, error) {
-	values := make([]interface{}, 0)
-	for _, value := range d.values {
-		values = append(values, value)
+	values := make([]interface{}, 0, d.length)
+	for e := d.first; e != nil; e = e.next {
+		values = append(values, e.value)
 	}
 	tup, err := tuple.MakeTupleFromValues(values...)
 	if err != nil {
@@ -187,19 +246,14 @@ func (d *Dict) Values() (tuple.TupleInterface, error) {
 	return tup, nil
 }
 
-/* Items returns a tuple of key/value pairs. */
+/* Items returns a tuple of key/value pairs in insertion order. */
 func (d *Dict) Items() (tuple.TupleInterface, error) {
 	l, err := list.MakeList()
 	if err != nil {
 		return nil, err
 	}
-	var value interface{}
-	for _, key := range d.keys {
-		value, err = d.Get(key)
-		if err != nil {
-			return nil, err
-		}
-		tup, errt := tuple.MakeTupleFromValues(key, value)
+	for e := d.first; e != nil; e = e.next {
+		tup, errt := tuple.MakeTupleFromValues(e.key, e.value)
 		if errt != nil {
 			return nil, errt
 		}
@@ -213,66 +267,161 @@ func (d *Dict) Items() (tuple.TupleInterface, error) {
 
 /* Copy creates a copy of the current DictInterface */
 func (d *Dict) Copy() (DictInterface, error) {
-	keys := make([]interface{}, 0)
-	values := make([]interface{}, 0)
-	for _, key := range d.keys {
-		value, err := d.Get(key)
-		if err != nil {
-			return nil, err
-		}
-		keys = append(keys, key)
-		values = append(values, value)
+	keys := make([]interface{}, 0, d.length)
+	values := make([]interface{}, 0, d.length)
+	for e := d.first; e != nil; e = e.next {
+		keys = append(keys, e.key)
+		values = append(values, e.value)
 	}
-	output, err := MakeDictFromKeyValues(keys, values)
+	output, err := makeDictFromKeyValues(d.hasher, keys, values)
 	return output, err
 }
 
-/* String returns a string representation of the dict. */
+/* String returns a string representation of the dict, in insertion order. */
 func (d *Dict) String() string {
 	output := "{"
-	for key := range d.Iterate() {
-		value, err := d.Get(key)
-		if err != nil {
-			return ""
-		}
-		output += fmt.Sprintf("(%v %v) ", key, value)
+	for e := d.first; e != nil; e = e.next {
+		output += fmt.Sprintf("(%v %v) ", e.key, e.value)
 	}
 	output = strings.Trim(output, " ") + "}"
 	return output
 }
 
+/* Hasher returns the Hasher used to hash keys in the dict. */
+func (d *Dict) Hasher() hasher.Hasher {
+	return d.hasher
+}
+
+/* lookup returns the hash of the key and the index of the key in its bucket. The index is -1 if the key is not in the dict. */
+func (d *Dict) lookup(key interface{}) (string, int, error) {
+	hash, err := hasher.Hash(d.hasher, key)
+	if err != nil {
+		return "", -1, err
+	}
+	return hash, d.find(hash, key), nil
+}
+
+/* find returns the index of the key in the bucket with the given hash, or -1 if the key is not in the dict. */
+func (d *Dict) find(hash string, key interface{}) int {
+	for i, e := range d.buckets[hash] {
+		if equality.Equal(key, e.key) {
+			return i
+		}
+	}
+	return -1
+}
+
+/* store sets the value of the entry at index i of the bucket with the given hash, or adds a new entry if i is -1. */
+func (d *Dict) store(hash string, i int, key interface{}, value interface{}) {
+	if i >= 0 {
+		d.buckets[hash][i].value = value
+		return
+	}
+	e := &entry{key: key, value: value}
+	d.buckets[hash] = append(d.buckets[hash], e)
+	d.link(e)
+	d.length += 1
+}
+
+/* delete removes the entry at index i of the bucket with the given hash. */
+func (d *Dict) delete(hash string, i int) {
+	bucket := d.buckets[hash]
+	d.unlink(bucket[i])
+	bucket[i].removed = true
+	if len(bucket) == 1 {
+		delete(d.buckets, hash)
+	} else {
+		d.buckets[hash] = append(bucket[:i:i], bucket[i+1:]...)
+	}
+	d.length -= 1
+}
+
+/* link adds the entry to the end of the insertion order. */
+func (d *Dict) link(e *entry) {
+	e.prev, e.next = d.last, nil
+	if d.last == nil {
+		d.first = e
+	} else {
+		d.last.next = e
+	}
+	d.last = e
+}
+
+/* unlink removes the entry from the insertion order. The entry keeps its next pointer, so ForEach can continue from it. */
+func (d *Dict) unlink(e *entry) {
+	if e.prev == nil {
+		d.first = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		d.last = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+}
+
+/* popEntry removes the entry from the dict and returns its key and value, or ErrEmpty if the entry is nil because the dict is empty. */
+func (d *Dict) popEntry(e *entry) (interface{}, interface{}, error) {
+	if e == nil {
+		return nil, nil, &errs.EmptyError{Op: "pop from", Structure: "dict"}
+	}
+	if err := d.Remove(e.key); err != nil {
+		return nil, nil, err
+	}
+	return e.key, e.value, nil
+}
+
 /* Init initializes the dict. */
 func (d *Dict) Init() {
-	d.keys = make(map[string]interface{})
-	d.values = make(map[string]interface{})
+	if d.hasher == nil {
+		d.hasher = hasher.Default()
+	}
+	for e := d.first; e != nil; e = e.next {
+		e.removed = true
+	}
+	d.buckets = make(map[string][]*entry)
+	d.first, d.last = nil, nil
+	d.length = 0
 }
 
 /* MakeDict initializes a new dict object using an Iterable. Every even-indexed element is a key and odd-indexed element is a value. */
 func MakeDict(it ...iterable.Iterable) (DictInterface, error) {
-	output := new(Dict)
+	output, err := MakeDictWithHasher(hasher.Default(), it...)
+	return output, err
+}
+
+/* MakeDictWithHasher initializes a new dict object which hashes keys using the given Hasher. */
+func MakeDictWithHasher(h hasher.Hasher, it ...iterable.Iterable) (DictInterface, error) {
+	output := &Dict{hasher: h}
 	output.Init()
 	if len(it) > 0 {
 		i := 0
-		iter := it[0]
 		var key interface{}
-		for v := range iter.Iterate() {
+		iterable.ForEach(it[0], func(v interface{}) bool {
 			if i%2 == 0 {
 				key = v
 			} else {
 				output.Set(key, v)
 			}
 			i += 1
-		}
+			return true
+		})
 	}
 	return output, nil
 }
 
 /* MakeDictFromKeyValues initializes a new dict object using keys and values. */
 func MakeDictFromKeyValues(keys []interface{}, values []interface{}) (DictInterface, error) {
-	output := new(Dict)
+	output, err := makeDictFromKeyValues(hasher.Default(), keys, values)
+	return output, err
+}
+
+func makeDictFromKeyValues(h hasher.Hasher, keys []interface{}, values []interface{}) (DictInterface, error) {
+	output := &Dict{hasher: h}
 	output.Init()
 	if len(keys) != len(values) {
-		return nil, fmt.Errorf("Number of keys does not match number of value")
+		return nil, &errs.LengthMismatchError{Length: len(values), Expected: len(keys), Of: "values"}
 	}
 	for i, key := range keys {
 		value := values[i]
@@ -281,13 +430,13 @@ func MakeDictFromKeyValues(keys []interface{}, values []interface{}) (DictInterf
 	return output, nil
 }
 
-/* MakeDictFromItems initializes a new dict object using . */
+/* MakeDictFromItems initializes a new dict object using (key, value) tuples. */
 func MakeDictFromItems(items ...tuple.TupleInterface) (DictInterface, error) {
 	output := new(Dict)
 	output.Init()
 	for _, item := range items {
 		if item.Length() != 2 {
-			return nil, fmt.Errorf("Each item must be of length 2 (key, value)")
+			return nil, &errs.LengthMismatchError{Length: item.Length(), Expected: 2, Of: "item"}
 		}
 		key, err0 := item.Get(0)
 		if err0 != nil {
@@ -301,3 +450,37 @@ func MakeDictFromItems(items ...tuple.TupleInterface) (DictInterface, error) {
 	}
 	return output, nil
 }
+
+/* hashKey returns the bytes which identify the items of the dict, made from the sorted hashes of each key and value so that the order of the keys does not change the key. */
+func hashKey(d DictInterface) ([]byte, error) {
+	h := hasher.Default()
+	hashes := make([]string, 0, d.Length())
+	var err error
+	d.ForEach(func(key interface{}) bool {
+		var value interface{}
+		var keyHash, valueHash string
+		if value, err = d.Get(key); err != nil {
+			return false
+		}
+		if keyHash, err = hasher.Hash(h, key); err != nil {
+			return false
+		}
+		if value != nil {
+			if valueHash, err = hasher.Hash(h, value); err != nil {
+				return false
+			}
+		}
+		hashes = append(hashes, keyHash+":"+valueHash)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(hashes)
+	output := make([]byte, 0)
+	for _, hash := range hashes {
+		output = append(output, hash...)
+		output = append(output, ',')
+	}
+	return output, nil
+}
@@ -1,42 +1,19 @@
 package dict
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"runtime"
 	"testing"
 
+	"github.com/dynago/dg/errs"
 	"github.com/dynago/dg/tuple"
 )
 
-/* permutation code from https://yourbasic.org/golang/generate-permutation-slice-string/ */
-
-// Perm calls f with each permutation of a.
-func perm(a []string, f func([]string)) {
-	permHelper(a, f, 0)
-}
-
-// Permute the values at index i to len(a)-1.
-func permHelper(a []string, f func([]string), i int) {
-	if i > len(a) {
-		f(a)
-		return
-	}
-	permHelper(a, f, i+1)
-	for j := i + 1; j < len(a); j++ {
-		a[i], a[j] = a[j], a[i]
-		permHelper(a, f, i+1)
-		a[i], a[j] = a[j], a[i]
-	}
-}
-
 func printChecker(str string, items []string) bool {
-	output := false
-	perm(items, func(a []string) {
-		potentialMatch := fmt.Sprint(a)
-		if potentialMatch[1:len(potentialMatch)-1] == str[1:len(str)-1] {
-			output = true
-		}
-	})
-	return output
+	expected := fmt.Sprint(items)
+	return expected[1:len(expected)-1] == str[1:len(str)-1]
 }
 
 func TestMakeEmptyDict(t *testing.T) {
@@ -142,7 +119,7 @@ func TestRemove(t *testing.T) {
 
 	vals := []string{"(1 2.2)"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -181,14 +158,14 @@ func TestSet(t *testing.T) {
 	if err := s.Set(100, -200.2); err != nil {
 		t.Error(err)
 	} else if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	vals = []string{"(1 -2.2)", "(hello 42)", "(100 -200.2)"}
 	if err := s.Set(1, -2.2); err != nil {
 		t.Error(err)
 	} else if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	if err := s.Set(nil, -2.2); err == nil {
@@ -218,7 +195,7 @@ func TestCombine(t *testing.T) {
 
 	vals := []string{"(1 2.2)", "(hello 42)", "(3 3)"}
 	if ok := printChecker(s1.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s1.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s1.String(), vals)
 	}
 
 	if err := s2.Combine(s3); err != nil {
@@ -227,7 +204,7 @@ func TestCombine(t *testing.T) {
 
 	vals = []string{"(3 3)"}
 	if ok := printChecker(s2.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s1.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s1.String(), vals)
 	}
 }
 
@@ -237,27 +214,15 @@ func TestPopKey(t *testing.T) {
 		t.Error(err1)
 	}
 
-	key, err := s.PopKey()
-	if err != nil {
+	if key, err := s.PopKey(); err != nil {
 		t.Error(err)
-	}
-	var vals []string
-	switch key {
-	case 1:
-		vals = append(vals, "(2 2)")
-		vals = append(vals, "(3 3)")
-	case 2:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(3 3)")
-	case 3:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(2 2)")
-	default:
-		t.Errorf("Pop popped an unexpected value: %s", key)
+	} else if key != 3 {
+		t.Errorf("Pop popped an unexpected value: %v", key)
 	}
 
+	vals := []string{"(1 1)", "(2 2)"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -267,28 +232,15 @@ func TestPopValue(t *testing.T) {
 		t.Error(err1)
 	}
 
-	value, err := s.PopValue()
-	if err != nil {
+	if value, err := s.PopValue(); err != nil {
 		t.Error(err)
+	} else if value != 3 {
+		t.Errorf("Pop popped an unexpected value: %v", value)
 	}
 
-	var vals []string
-	switch value {
-	case 1:
-		vals = append(vals, "(2 2)")
-		vals = append(vals, "(3 3)")
-	case 2:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(3 3)")
-	case 3:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(2 2)")
-	default:
-		t.Errorf("Pop popped an unexpected value: %s", value)
-	}
-
+	vals := []string{"(1 1)", "(2 2)"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -298,29 +250,71 @@ func TestPop(t *testing.T) {
 		t.Error(err1)
 	}
 
-	key, value, err := s.Pop()
-	if err != nil {
+	if key, value, err := s.Pop(); err != nil {
 		t.Error(err)
-	} else if key != value {
+	} else if key != 3 || value != 3 {
 		t.Fatalf("Pop did not pop correctly")
 	}
-	var vals []string
-	switch value {
-	case 1:
-		vals = append(vals, "(2 2)")
-		vals = append(vals, "(3 3)")
-	case 2:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(3 3)")
-	case 3:
-		vals = append(vals, "(1 1)")
-		vals = append(vals, "(2 2)")
-	default:
-		t.Errorf("Pop popped an unexpected value: %s", value)
-	}
 
+	vals := []string{"(1 1)", "(2 2)"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	s, err1 := MakeDictFromKeyValues([]interface{}{"c", "a", "b"}, []interface{}{3, 1, 2})
+	if err1 != nil {
+		t.Error(err1)
+	}
+	o := s.(*Dict)
+
+	if err := o.Set("c", 4); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(o.String(), []string{"(c 4)", "(a 1)", "(b 2)"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", o.String())
+	}
+
+	if err := o.MoveToEnd("c"); err != nil {
+		t.Error(err)
+	} else if keys, err := o.Keys(); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(keys.String(), []string{"a", "b", "c"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", keys.String())
+	}
+
+	if err := o.MoveToEnd("d"); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Expected missing key error")
+	}
+
+	if key, value, err := o.PopFirst(); err != nil {
+		t.Error(err)
+	} else if key != "a" || value != 1 {
+		t.Fatalf("Got %v and %v, was expecting a and 1", key, value)
+	}
+
+	if key, value, err := o.PopLast(); err != nil {
+		t.Error(err)
+	} else if key != "c" || value != 4 {
+		t.Fatalf("Got %v and %v, was expecting c and 4", key, value)
+	}
+
+	if err := o.Remove("b"); err != nil {
+		t.Error(err)
+	} else if key, value, err := o.PopFirst(); !errors.Is(err, ErrEmpty) || key != nil || value != nil {
+		t.Fatalf("Got %v, %v and %v, was expecting ErrEmpty from an empty dict", key, value, err)
+	}
+
+	o.Set(1, 1)
+	if key, _, err := Synchronized(s).(OrderedDictInterface).PopLast(); err != nil {
+		t.Error(err)
+	} else if key != 1 {
+		t.Fatalf("Got %v, was expecting 1", key)
+	}
+
+	c, _ := MakeConcurrent()
+	if err := Synchronized(c).(OrderedDictInterface).MoveToEnd(1); err == nil {
+		t.Fatalf("Expected insertion order error")
 	}
 }
 
@@ -339,7 +333,7 @@ func TestClear(t *testing.T) {
 
 	vals := []string{}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	// Check that we can still add
@@ -480,3 +474,402 @@ func TestItems(t *testing.T) {
 		t.Fatalf("Got %s, which was unexpected", items.String())
 	}
 }
+
+type countingHasher struct {
+	calls int
+}
+
+func (h *countingHasher) Hash(value interface{}) (string, error) {
+	h.calls++
+	return fmt.Sprintf("%T:%v", value, value), nil
+}
+
+func TestMakeDictWithHasher(t *testing.T) {
+	h := new(countingHasher)
+	s, err := MakeDictWithHasher(h)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := s.Set(1, "one"); err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get(1); err != nil {
+		t.Error(err)
+	} else if value != "one" {
+		t.Fatalf("Expected value to be one, got %v", value)
+	}
+	if h.calls != 2 {
+		t.Fatalf("Expected the hasher to be called 2 times, got %d", h.calls)
+	}
+
+	c, err := s.Copy()
+	if err != nil {
+		t.Error(err)
+	}
+	if c.(*Dict).Hasher() != h {
+		t.Fatal("Copy did not keep the hasher of the dict")
+	}
+
+	if err := s.Clear(); err != nil {
+		t.Error(err)
+	}
+	if s.(*Dict).Hasher() != h {
+		t.Fatal("Clear did not keep the hasher of the dict")
+	}
+}
+
+type hashableTester struct {
+	id   int
+	name string
+}
+
+func (h hashableTester) HashKey() ([]byte, error) {
+	return []byte(fmt.Sprint(h.id)), nil
+}
+
+func (h hashableTester) EqualTo(other interface{}) bool {
+	o, ok := other.(hashableTester)
+	return ok && o.id == h.id
+}
+
+func TestHashable(t *testing.T) {
+	s, err := MakeDict()
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := s.Set(hashableTester{1, "a"}, 1); err != nil {
+		t.Error(err)
+	}
+	if err := s.Set(hashableTester{2, "a"}, 2); err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 2 {
+		t.Fatalf("Expected the length of the dict to be 2, got %d", s.Length())
+	}
+
+	if value, err := s.Get(hashableTester{1, "b"}); err != nil {
+		t.Error(err)
+	} else if value != 1 {
+		t.Fatalf("Expected value to be 1, got %v", value)
+	}
+
+	if contains, err := s.Contains(hashableTester{3, "a"}); err != nil {
+		t.Error(err)
+	} else if contains == true {
+		t.Fatal("The dict contains {3 a} when it shouldn't")
+	}
+
+	if err := s.Remove(hashableTester{2, "b"}); err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 1 {
+		t.Fatalf("Expected the length of the dict to be 1, got %d", s.Length())
+	}
+}
+
+func TestTupleKeys(t *testing.T) {
+	s, err := MakeDict()
+	if err != nil {
+		t.Error(err)
+	}
+
+	k1, _ := tuple.MakeTupleFromValues(1, "a")
+	k2, _ := tuple.MakeTupleFromValues(1, "a")
+	k3, _ := tuple.MakeTupleFromValues("a", 1)
+
+	if err := s.Set(k1, 1); err != nil {
+		t.Error(err)
+	}
+	if err := s.Set(k3, 3); err != nil {
+		t.Error(err)
+	}
+
+	if value, err := s.Get(k2); err != nil {
+		t.Error(err)
+	} else if value != 1 {
+		t.Fatalf("Expected value to be 1, got %v", value)
+	}
+
+	if err := s.Set(k2, 2); err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 2 {
+		t.Fatalf("Expected the length of the dict to be 2, got %d", s.Length())
+	}
+	if value, err := s.Get(k1); err != nil {
+		t.Error(err)
+	} else if value != 2 {
+		t.Fatalf("Expected value to be 2, got %v", value)
+	}
+}
+
+type collidingHasher struct{}
+
+func (collidingHasher) Hash(value interface{}) (string, error) {
+	return "collision", nil
+}
+
+func TestCollidingHasher(t *testing.T) {
+	s, err := MakeDictWithHasher(collidingHasher{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	keys := []interface{}{1, 1.0, "1", 2, []int{1}}
+	for i, key := range keys {
+		if err := s.Set(key, i); err != nil {
+			t.Error(err)
+		}
+	}
+	if s.Length() != len(keys) {
+		t.Fatalf("Expected the length of the dict to be %d, got %d", len(keys), s.Length())
+	}
+	for i, key := range keys {
+		if value, err := s.Get(key); err != nil {
+			t.Error(err)
+		} else if value != i {
+			t.Fatalf("Expected value of %v to be %d, got %v", key, i, value)
+		}
+	}
+
+	if err := s.Set(1.0, "one"); err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get(1); err != nil {
+		t.Error(err)
+	} else if value != 0 {
+		t.Fatalf("Expected value of 1 to be 0, got %v", value)
+	}
+
+	if err := s.Remove("1"); err != nil {
+		t.Error(err)
+	}
+	if contains, err := s.Contains("1"); err != nil {
+		t.Error(err)
+	} else if contains == true {
+		t.Fatal("The dict contains \"1\" when it shouldn't")
+	}
+	if contains, err := s.Contains(2); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The dict does not contain 2 when it should")
+	}
+	if s.Length() != len(keys)-1 {
+		t.Fatalf("Expected the length of the dict to be %d, got %d", len(keys)-1, s.Length())
+	}
+
+	c, err := s.Copy()
+	if err != nil {
+		t.Error(err)
+	}
+	if equals, err := c.Equals(s); err != nil {
+		t.Error(err)
+	} else if equals == false {
+		t.Fatal("The copy should be equal to the dict, but it is not")
+	}
+}
+
+func TestDeepEquality(t *testing.T) {
+	s1, err := MakeDictFromKeyValues([]interface{}{1, 2}, []interface{}{[]int{1}, nil})
+	if err != nil {
+		t.Error(err)
+	}
+	s2, err := MakeDictFromKeyValues([]interface{}{1, 2}, []interface{}{[]int{1}, nil})
+	if err != nil {
+		t.Error(err)
+	}
+	s3, err := MakeDictFromKeyValues([]interface{}{1, 3}, []interface{}{[]int{1}, nil})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if equals, err := s1.Equals(s2); err != nil {
+		t.Error(err)
+	} else if equals == false {
+		t.Fatal("s1 and s2 should be equal, but they are not")
+	}
+
+	if equals, err := s1.Equals(s3); err != nil {
+		t.Error(err)
+	} else if equals == true {
+		t.Fatal("s1 and s3 should not be equal, but they are")
+	}
+}
+
+func TestPopDoesNotLeak(t *testing.T) {
+	keys := make([]interface{}, 100)
+	for i := range keys {
+		keys[i] = i
+	}
+	s, err := MakeDictFromKeyValues(keys, keys)
+	if err != nil {
+		t.Error(err)
+	}
+
+	before := runtime.NumGoroutine()
+	for s.Length() > 0 {
+		if _, err := s.PopKey(); err != nil {
+			t.Error(err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("Expected no more than %d goroutines, got %d", before, after)
+	}
+}
+
+func TestForEachRemove(t *testing.T) {
+	s, _ := MakeDictFromKeyValues([]interface{}{1, 2, 3, 4}, []interface{}{1, 2, 3, 4})
+	keys := make([]string, 0)
+	s.ForEach(func(key interface{}) bool {
+		keys = append(keys, fmt.Sprint(key))
+		if key == 1 {
+			s.Remove(1)
+			s.Remove(2)
+		}
+		return true
+	})
+	if fmt.Sprint(keys) != "[1 3 4]" {
+		t.Fatalf("Got %v, was expecting removed keys not to be visited", keys)
+	}
+
+	keys = keys[:0]
+	s.ForEach(func(key interface{}) bool {
+		keys = append(keys, fmt.Sprint(key))
+		s.Clear()
+		return true
+	})
+	if fmt.Sprint(keys) != "[3]" {
+		t.Fatalf("Got %v, was expecting no keys after clearing", keys)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s, err := MakeDictFromKeyValues([]interface{}{1, 2, 3}, []interface{}{1, 2, 3})
+	if err != nil {
+		t.Error(err)
+	}
+
+	count := 0
+	s.ForEach(func(key interface{}) bool {
+		count += 1
+		return false
+	})
+	if count != 1 {
+		t.Fatalf("Expected ForEach to stop after 1 key, got %d", count)
+	}
+
+	iter := s.Iterator()
+	defer iter.Close()
+	vals := make([]string, 0)
+	for iter.Next() {
+		vals = append(vals, fmt.Sprint(iter.Value()))
+		if err := s.Remove(iter.Value()); err != nil {
+			t.Error(err)
+		}
+	}
+	if ok := printChecker(fmt.Sprint(vals), []string{"1", "2", "3"}); !ok {
+		t.Fatalf("Got %v, which was unexpected", vals)
+	}
+	if s.Length() != 0 {
+		t.Fatalf("Expected the length of the dict to be 0, got %d", s.Length())
+	}
+}
+
+func TestLookup(t *testing.T) {
+	d, _ := MakeDictFromKeyValues([]interface{}{"a", "n"}, []interface{}{1, nil})
+	sorted, _ := MakeSortedDict()
+	concurrent, _ := MakeConcurrent()
+	def, _ := MakeDefaultDict(func() interface{} { return 0 })
+	dicts := []DictInterface{d, Synchronized(d), sorted, concurrent, def}
+	for _, dict := range dicts[2:] {
+		dict.Set("a", 1)
+		dict.Set("n", nil)
+	}
+
+	for _, dict := range dicts {
+		if value, found, err := dict.Lookup("a"); err != nil || !found || value != 1 {
+			t.Errorf("%T: got %v and %v, was expecting 1 and true", dict, value, found)
+		} else if value, found, err := dict.Lookup("n"); err != nil || !found || value != nil {
+			t.Errorf("%T: got %v and %v, was expecting nil and true", dict, value, found)
+		} else if value, found, err := dict.Lookup("z"); err != nil || found || value != nil {
+			t.Errorf("%T: got %v and %v, was expecting nil and false", dict, value, found)
+		} else if value, err := dict.GetOr("z", "default"); err != nil || value != "default" {
+			t.Errorf("%T: got %v, was expecting default", dict, value)
+		} else if value, err := dict.GetOr("n", "default"); err != nil || value != nil {
+			t.Errorf("%T: got %v, was expecting the stored nil", dict, value)
+		} else if dict.Length() != 2 {
+			t.Errorf("%T: expected Lookup and GetOr not to add keys, got %v", dict, dict)
+		}
+	}
+
+	if _, _, err := d.Lookup(nil); !errors.Is(err, errs.ErrUnhashable) {
+		t.Errorf("Got %v, was expecting ErrUnhashable", err)
+	} else if _, err := sorted.GetOr([]int{1}, 0); err == nil {
+		t.Error("Expected error for incomparable key")
+	}
+
+	for _, dict := range dicts {
+		dict.Clear()
+		if _, _, err := dict.Pop(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%T: got %v from Pop, was expecting ErrEmpty", dict, err)
+		} else if _, err := dict.PopKey(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%T: got %v from PopKey, was expecting ErrEmpty", dict, err)
+		} else if _, err := dict.PopValue(); !errors.Is(err, ErrEmpty) {
+			t.Errorf("%T: got %v from PopValue, was expecting ErrEmpty", dict, err)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	d, _ := MakeDictFromKeyValues([]interface{}{"a"}, []interface{}{1})
+
+	var keyErr *errs.KeyError
+	if err := d.(*Dict).MoveToEnd("z"); !errors.As(err, &keyErr) {
+		t.Fatalf("Got %v, was expecting a KeyError", err)
+	} else if keyErr.Key != "z" || !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("Got %+v, was expecting key z", keyErr)
+	}
+
+	var hashErr *errs.HashError
+	var marshalErr *json.UnsupportedTypeError
+	if err := d.Set(make(chan int), 1); !errors.As(err, &hashErr) {
+		t.Fatalf("Got %v, was expecting a HashError", err)
+	} else if !errors.As(err, &marshalErr) || !errors.Is(err, errs.ErrUnhashable) {
+		t.Fatalf("Got %v, was expecting it to wrap the marshal failure", err)
+	}
+
+	var lengthErr *errs.LengthMismatchError
+	if _, err := MakeDictFromKeyValues([]interface{}{1, 2}, []interface{}{1}); !errors.As(err, &lengthErr) {
+		t.Fatalf("Got %v, was expecting a LengthMismatchError", err)
+	} else if lengthErr.Length != 1 || lengthErr.Expected != 2 || !errors.Is(err, errs.ErrLengthMismatch) {
+		t.Fatalf("Got %+v, was expecting 1 value for 2 keys", lengthErr)
+	}
+	item, _ := tuple.MakeTupleFromValues("a", 1, 2)
+	if _, err := MakeDictFromItems(item); !errors.As(err, &lengthErr) {
+		t.Fatalf("Got %v, was expecting a LengthMismatchError", err)
+	} else if lengthErr.Length != 3 || lengthErr.Expected != 2 {
+		t.Fatalf("Got %+v, was expecting an item of length 3", lengthErr)
+	}
+
+	var emptyErr *errs.EmptyError
+	d.Clear()
+	if _, _, err := d.Pop(); !errors.As(err, &emptyErr) || emptyErr.Structure != "dict" {
+		t.Fatalf("Got %v, was expecting an EmptyError", err)
+	}
+}
+
+func TestDictHashKey(t *testing.T) {
+	d1, _ := MakeDictFromKeyValues([]interface{}{"a", "b"}, []interface{}{1, nil})
+	d2, _ := MakeDictFromKeyValues([]interface{}{"b", "a"}, []interface{}{nil, 1})
+	d3, _ := MakeDictFromKeyValues([]interface{}{"a", "b"}, []interface{}{2, nil})
+	outer, _ := MakeDictFromKeyValues([]interface{}{d1}, []interface{}{"found"})
+	if value, found, err := outer.Lookup(d2); err != nil {
+		t.Error(err)
+	} else if !found || value != "found" {
+		t.Fatal("Expected a dict with the same items in another order to be found")
+	} else if _, found, _ := outer.Lookup(d3); found {
+		t.Fatal("Expected a dict with another value not to be found")
+	}
+}
@@ -0,0 +1,10 @@
+package dict
+
+import "github.com/dynago/dg/errs"
+
+var (
+	// ErrKeyNotFound is matched by the *errs.KeyError returned when a key which must be in the dict is not. It is errs.ErrKeyNotFound.
+	ErrKeyNotFound = errs.ErrKeyNotFound
+	// ErrEmpty is matched by the *errs.EmptyError returned when popping from an empty dict. It is errs.ErrEmpty.
+	ErrEmpty = errs.ErrEmpty
+)
@@ -1,6 +1,7 @@
 package dict
 
 import (
+	"github.com/dynago/dg/iterable"
 	"github.com/dynago/dg/tuple"
 )
 
@@ -10,20 +11,28 @@ type DictInterface interface {
 	Length() int
 	/* Return the next key in dict. */
 	Iterate() <-chan interface{}
+	/* Call the function on each key in dict until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the keys in dict. */
+	Iterator() iterable.Iterator
 
 	/* Remove key from the dict. */
 	Remove(interface{}) error
 	/* Returns the value at given key. */
 	Get(interface{}) (interface{}, error)
+	/* Returns the value at given key, and whether the key is in the dict. */
+	Lookup(interface{}) (interface{}, bool, error)
+	/* Returns the value at given key, or the default if the key is not in the dict. */
+	GetOr(interface{}, interface{}) (interface{}, error)
 	/* Sets the value at given key to given value. */
 	Set(interface{}, interface{}) error
 	/* Update the dict, adding elements from the other dict. Old values are replaced with new. */
 	Combine(DictInterface) error
-	/* Pop and return an arbitrary key from the dict. */
+	/* Pop and return an arbitrary key from the dict. Returns ErrEmpty if the dict is empty. */
 	PopKey() (interface{}, error)
-	/* Pop and return an arbitrary value from the dict. */
+	/* Pop and return an arbitrary value from the dict. Returns ErrEmpty if the dict is empty. */
 	PopValue() (interface{}, error)
-	/* Pop and return an arbitrary item from the dict. */
+	/* Pop and return an arbitrary item from the dict. Returns ErrEmpty if the dict is empty. */
 	Pop() (interface{}, interface{}, error)
 	/* Clear all elements from the dict. */
 	Clear() error
@@ -49,3 +58,15 @@ type DictInterface interface {
 	/* Initializes the dict. */
 	Init()
 }
+
+// OrderedDictInterface is the interface implemented by dicts which keep their keys in insertion order.
+type OrderedDictInterface interface {
+	DictInterface
+
+	/* Move the key to the end of the dict. */
+	MoveToEnd(interface{}) error
+	/* Pop and return the least recently inserted key and value. */
+	PopFirst() (interface{}, interface{}, error)
+	/* Pop and return the most recently inserted key and value. */
+	PopLast() (interface{}, interface{}, error)
+}
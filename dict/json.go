@@ -0,0 +1,191 @@
+package dict
+
+import (
+	"fmt"
+
+	"github.com/dynago/dg/internal/jsonvalue"
+)
+
+// KeyEncoding is how a dict whose keys are not all strings is encoded as JSON.
+type KeyEncoding int
+
+const (
+	// KeysAsPairs encodes the dict as an array of [key, value] pairs, which decodes back to the same keys. It is the encoding used by MarshalJSON.
+	KeysAsPairs KeyEncoding = iota
+	// KeysAsStrings encodes the dict as an object whose keys are formatted with fmt.Sprint, which decodes to string keys.
+	KeysAsStrings
+	// KeysRejected returns an error.
+	KeysRejected
+)
+
+// Encoder encodes a dict as JSON using a KeyEncoding for dicts with a key which is not a string. Dicts with only string keys are always encoded as objects. Dicts nested in the values are encoded with KeysAsPairs.
+type Encoder struct {
+	Dict DictInterface
+	Keys KeyEncoding
+}
+
+/* MarshalJSON encodes the dict as a JSON object, or according to the KeyEncoding if it has a key which is not a string. */
+func (e Encoder) MarshalJSON() ([]byte, error) {
+	if m, ok := e.Dict.(interface {
+		marshalJSONWith(KeyEncoding) ([]byte, error)
+	}); ok {
+		return m.marshalJSONWith(e.Keys)
+	}
+	return marshalJSON(e.Dict, e.Keys)
+}
+
+/* WithKeyEncoding returns an Encoder which encodes the dict with the KeyEncoding, for passing to json.Marshal in place of the dict. */
+func WithKeyEncoding(d DictInterface, keys KeyEncoding) Encoder {
+	return Encoder{Dict: d, Keys: keys}
+}
+
+func init() {
+	jsonvalue.MakeDict = func(keys []interface{}, values []interface{}) (interface{}, error) {
+		return MakeDictFromKeyValues(keys, values)
+	}
+}
+
+/* MarshalJSON encodes the dict as a JSON object, or as an array of [key, value] pairs if it has a key which is not a string. Use WithKeyEncoding to encode it another way. */
+func (d *Dict) MarshalJSON() ([]byte, error) {
+	return d.marshalJSONWith(KeysAsPairs)
+}
+
+/* marshalJSONWith encodes the dict with the KeyEncoding. */
+func (d *Dict) marshalJSONWith(keys KeyEncoding) ([]byte, error) {
+	return marshalJSON(d, keys)
+}
+
+/* UnmarshalJSON decodes a JSON object, or an array of [key, value] pairs, into the dict. Nested objects become dicts and nested arrays become lists, or tuples if they are keys. */
+func (d *Dict) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(d, data)
+}
+
+/* UnmarshalJSON decodes a JSON object, or an array of [key, value] pairs, into the dict, keeping its factory. */
+func (d *DefaultDict) UnmarshalJSON(data []byte) error {
+	if d.Dict == nil {
+		d.Dict = new(Dict)
+	}
+	return unmarshalJSON(d.Dict, data)
+}
+
+/* MarshalJSON encodes the dict as a JSON object, or as an array of [key, value] pairs if it has a key which is not a string. Use WithKeyEncoding to encode it another way. */
+func (d *SyncDict) MarshalJSON() ([]byte, error) {
+	return d.marshalJSONWith(KeysAsPairs)
+}
+
+/* marshalJSONWith encodes the dict with the KeyEncoding, holding the read lock. */
+func (d *SyncDict) marshalJSONWith(keys KeyEncoding) ([]byte, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return marshalJSON(d.dict, keys)
+}
+
+/* UnmarshalJSON decodes a JSON object, or an array of [key, value] pairs, into the dict. Nested objects become dicts and nested arrays become lists, or tuples if they are keys. */
+func (d *SyncDict) UnmarshalJSON(data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dict == nil {
+		d.dict = new(Dict)
+	}
+	return unmarshalJSON(d.dict, data)
+}
+
+/* MarshalJSON encodes a snapshot of the dict as a JSON object, or as an array of [key, value] pairs if it has a key which is not a string. Use WithKeyEncoding to encode it another way. */
+func (d *Concurrent) MarshalJSON() ([]byte, error) {
+	return d.marshalJSONWith(KeysAsPairs)
+}
+
+/* marshalJSONWith encodes a snapshot of the dict with the KeyEncoding. */
+func (d *Concurrent) marshalJSONWith(keys KeyEncoding) ([]byte, error) {
+	snapshot, err := d.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return marshalJSON(snapshot, keys)
+}
+
+/* UnmarshalJSON decodes a JSON object, or an array of [key, value] pairs, into the dict. Nested objects become dicts and nested arrays become lists, or tuples if they are keys. */
+func (d *Concurrent) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(d, data)
+}
+
+/* MarshalJSON encodes the dict as a JSON object with keys in sorted order, or as an array of [key, value] pairs if it has a key which is not a string. Use WithKeyEncoding to encode it another way. */
+func (d *SortedDict) MarshalJSON() ([]byte, error) {
+	return d.marshalJSONWith(KeysAsPairs)
+}
+
+/* marshalJSONWith encodes the dict with the KeyEncoding. */
+func (d *SortedDict) marshalJSONWith(keys KeyEncoding) ([]byte, error) {
+	return marshalJSON(d, keys)
+}
+
+/* UnmarshalJSON decodes a JSON object, or an array of [key, value] pairs, into the dict. Nested objects become dicts and nested arrays become lists, or tuples if they are keys. */
+func (d *SortedDict) UnmarshalJSON(data []byte) error {
+	return unmarshalJSON(d, data)
+}
+
+/* marshalJSON encodes the items of the dict in iteration order, or sorted by their encoding if the dict has no order. Keys which are not all strings are encoded with the KeyEncoding. */
+func marshalJSON(d DictInterface, encoding KeyEncoding) ([]byte, error) {
+	_, unordered := d.(*Concurrent)
+	keys := make([]interface{}, 0, d.Length())
+	values := make([]interface{}, 0, d.Length())
+	strs := make([]string, 0, d.Length())
+	allStrings := true
+	var err error
+	d.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = d.Get(key); err != nil {
+			return false
+		}
+		str, ok := key.(string)
+		allStrings = allStrings && ok
+		keys = append(keys, key)
+		values = append(values, value)
+		strs = append(strs, str)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if allStrings {
+		return jsonvalue.EncodeObject(strs, values, unordered)
+	}
+	switch encoding {
+	case KeysAsStrings:
+		seen := make(map[string]interface{}, len(keys))
+		for i, key := range keys {
+			strs[i] = fmt.Sprint(key)
+			if other, ok := seen[strs[i]]; ok {
+				return nil, fmt.Errorf("Keys %v and %v both encode as %q", other, key, strs[i])
+			}
+			seen[strs[i]] = key
+		}
+		return jsonvalue.EncodeObject(strs, values, unordered)
+	case KeysRejected:
+		return nil, fmt.Errorf("Cannot encode dict with non-string keys as JSON")
+	default:
+		pairs := make([]interface{}, len(keys))
+		for i := range keys {
+			pairs[i] = []interface{}{keys[i], values[i]}
+		}
+		return jsonvalue.EncodeArray(pairs, unordered)
+	}
+}
+
+/* unmarshalJSON replaces the items of the dict with the decoded items. */
+func unmarshalJSON(d DictInterface, data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	keys, values, err := jsonvalue.DecodeItems(data)
+	if err != nil {
+		return err
+	}
+	d.Init()
+	for i, key := range keys {
+		if err = d.Set(key, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
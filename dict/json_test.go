@@ -0,0 +1,108 @@
+package dict
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	d, _ := MakeDictFromKeyValues([]interface{}{"b", "a"}, []interface{}{[]int{1}, nil})
+	if b, err := json.Marshal(d); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"b":[1],"a":null}` {
+		t.Errorf("Expected object in insertion order, got %s", b)
+	}
+	c, _ := MakeConcurrent()
+	c.Set("b", 1)
+	c.Set("a", 2)
+	if b, err := json.Marshal(c); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"a":2,"b":1}` {
+		t.Errorf("Expected sorted object, got %s", b)
+	}
+	e, _ := MakeDict()
+	if b, err := json.Marshal(e); err != nil || string(b) != `{}` {
+		t.Errorf("Expected empty object, got %s", b)
+	}
+}
+
+func TestMarshalJSONNonStringKeys(t *testing.T) {
+	key, _ := tuple.MakeTupleFromValues(1, 2)
+	d, _ := MakeDictFromKeyValues([]interface{}{1, key}, []interface{}{"a", "b"})
+	if b, err := json.Marshal(d); err != nil {
+		t.Error(err)
+	} else if string(b) != `[[1,"a"],[[1,2],"b"]]` {
+		t.Errorf("Expected pairs, got %s", b)
+	}
+	if b, err := json.Marshal(WithKeyEncoding(d, KeysAsStrings)); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"1":"a","(1 2)":"b"}` {
+		t.Errorf("Expected string keys, got %s", b)
+	}
+	if b, err := json.Marshal(d); err != nil || string(b) != `[[1,"a"],[[1,2],"b"]]` {
+		t.Errorf("Expected pairs again without the encoder, got %s", b)
+	}
+	s := Synchronized(d)
+	d.Set("1", "c")
+	if _, err := json.Marshal(WithKeyEncoding(s, KeysAsStrings)); err == nil {
+		t.Error("Expected error for keys which format the same")
+	}
+	if _, err := json.Marshal(WithKeyEncoding(d, KeysRejected)); err == nil {
+		t.Error("Expected error for non-string keys")
+	}
+	strs, _ := MakeDictFromKeyValues([]interface{}{"a"}, []interface{}{1})
+	if b, err := json.Marshal(WithKeyEncoding(strs, KeysRejected)); err != nil || string(b) != `{"a":1}` {
+		t.Errorf("Expected object for string keys, got %s", b)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	d := new(Dict)
+	if err := json.Unmarshal([]byte(`{"a": [1, 2.5, {"b": null}], "c": true}`), d); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inner, _ := MakeDictFromKeyValues([]interface{}{"b"}, []interface{}{nil})
+	l, _ := list.MakeListFromValues(1, 2.5, inner)
+	expected, _ := MakeDictFromKeyValues([]interface{}{"a", "c"}, []interface{}{l, true})
+	if equals, err := d.Equals(expected); err != nil || !equals {
+		t.Errorf("Expected %v, got %v", expected, d)
+	}
+	if err := json.Unmarshal([]byte(`[1, 2]`), d); err == nil {
+		t.Error("Expected error for array which is not of pairs")
+	} else if err := json.Unmarshal([]byte(`"a"`), d); err == nil {
+		t.Error("Expected error for string")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	key, _ := tuple.MakeTupleFromValues(1, "x")
+	l, _ := list.MakeListFromValues(1, 2)
+	original, _ := MakeDictFromKeyValues([]interface{}{key, 3.5, "s"}, []interface{}{l, false, nil})
+	for _, d := range []DictInterface{original, Synchronized(original)} {
+		b, err := json.Marshal(d)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		decoded := Synchronized(new(Dict))
+		if err = json.Unmarshal(b, decoded); err != nil {
+			t.Errorf("Unexpected error decoding %s: %v", b, err)
+		} else if equals, err := decoded.Equals(original); err != nil || !equals {
+			t.Errorf("Expected %v, got %v", original, decoded)
+		}
+	}
+	c, _ := MakeConcurrent()
+	c.Set("a", 1)
+	b, err := json.Marshal(c)
+	if err != nil || string(b) != `{"a":1}` {
+		t.Errorf("Expected object, got %s", b)
+	}
+	decoded := new(Concurrent)
+	if err = json.Unmarshal(b, decoded); err != nil {
+		t.Error(err)
+	} else if v, _ := decoded.Get("a"); v != 1 {
+		t.Errorf("Expected 1, got %v", v)
+	}
+}
@@ -0,0 +1,324 @@
+package dict
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/internal/helpers"
+	"github.com/dynago/dg/internal/tree"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/order"
+	"github.com/dynago/dg/tuple"
+)
+
+// SortedDict is a dict which keeps its keys sorted by a comparison function, order.Compare by default. Keys which compare equal are the same key, so 1 and 1.0 are the same key in a sorted dict. Lookups, updates and rank queries take O(log n) time.
+type SortedDict struct {
+	compare func(a interface{}, b interface{}) (int, error)
+	tree    *tree.Tree
+}
+
+/* Length returns the number of elements in dict. */
+func (d *SortedDict) Length() int {
+	return d.tree.Len()
+}
+
+/* Iterate returns the next key in dict, in sorted order. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (d *SortedDict) Iterate() <-chan interface{} {
+	return iterable.Channel(d)
+}
+
+/* ForEach calls the function on each key in dict, in sorted order, until it returns false. The function may change the values of keys, but must not add or remove keys. */
+func (d *SortedDict) ForEach(fn func(interface{}) bool) {
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		return fn(key)
+	})
+}
+
+/* Iterator returns an Iterator over a snapshot of the keys in dict, in sorted order. */
+func (d *SortedDict) Iterator() iterable.Iterator {
+	return iterable.FromSlice(d.keys())
+}
+
+/* Remove removes element from the dict. */
+func (d *SortedDict) Remove(key interface{}) error {
+	_, _, _, err := d.tree.Delete(key)
+	return err
+}
+
+/* Get returns the value with given key. */
+func (d *SortedDict) Get(key interface{}) (interface{}, error) {
+	value, _, err := d.tree.Get(key)
+	return value, err
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. */
+func (d *SortedDict) Lookup(key interface{}) (interface{}, bool, error) {
+	return d.tree.Get(key)
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. */
+func (d *SortedDict) GetOr(key interface{}, def interface{}) (interface{}, error) {
+	value, found, err := d.tree.Get(key)
+	if err != nil || !found {
+		return def, err
+	}
+	return value, nil
+}
+
+/* Set sets the value at given key to given value. It returns an error if the key cannot be compared with the keys in the dict. */
+func (d *SortedDict) Set(key interface{}, value interface{}) error {
+	_, err := d.tree.Put(key, value)
+	return err
+}
+
+/* Combine updates the dict, adding elements from the other dict. Old values are replaced with new. */
+func (d *SortedDict) Combine(other DictInterface) error {
+	var err error
+	other.ForEach(func(key interface{}) bool {
+		var value interface{}
+		if value, err = other.Get(key); err != nil {
+			return false
+		}
+		err = d.Set(key, value)
+		return err == nil
+	})
+	return err
+}
+
+/* PopKey pops and returns the largest key from the dict, or ErrEmpty if it is empty. */
+func (d *SortedDict) PopKey() (interface{}, error) {
+	key, _, err := d.Pop()
+	return key, err
+}
+
+/* PopValue pops and returns the value of the largest key from the dict, or ErrEmpty if it is empty. */
+func (d *SortedDict) PopValue() (interface{}, error) {
+	_, value, err := d.Pop()
+	return value, err
+}
+
+/* Pop pops and returns the item with the largest key from the dict, or ErrEmpty if it is empty. */
+func (d *SortedDict) Pop() (interface{}, interface{}, error) {
+	key, _, ok := d.tree.Max()
+	if !ok {
+		return nil, nil, &errs.EmptyError{Op: "pop from", Structure: "dict"}
+	}
+	key, value, _, err := d.tree.Delete(key)
+	return key, value, err
+}
+
+/* Clear clears all elements from the dict. */
+func (d *SortedDict) Clear() error {
+	d.tree.Clear()
+	return nil
+}
+
+/* Contains tests for membership in the dict. */
+func (d *SortedDict) Contains(key interface{}) (bool, error) {
+	_, ok, err := d.tree.Get(key)
+	return ok, err
+}
+
+/* Equals returns true if the dict has all elements in common with the other dict. */
+func (d *SortedDict) Equals(other DictInterface) (bool, error) {
+	if d.Length() != other.Length() {
+		return false, nil
+	}
+	equals := true
+	var err error
+	other.ForEach(func(ok interface{}) bool {
+		var ov, v interface{}
+		if ov, err = other.Get(ok); err != nil {
+			return false
+		}
+		var found bool
+		if v, found, err = d.tree.Get(ok); err != nil {
+			return false
+		}
+		equals = found && equality.Equal(v, ov)
+		return equals
+	})
+	if err != nil {
+		return false, err
+	}
+	return equals, nil
+}
+
+/* HashKey returns the bytes which identify the dict, so it can be used as a dict key or set element. The order of the keys does not change the key. The dict must not be changed while it is a key. */
+func (d *SortedDict) HashKey() ([]byte, error) {
+	return hashKey(d)
+}
+
+/* EqualTo returns true if the other value is a dict with equal keys and values. */
+func (d *SortedDict) EqualTo(other interface{}) bool {
+	o, ok := other.(DictInterface)
+	if !ok {
+		return false
+	}
+	equals, err := d.Equals(o)
+	return err == nil && equals
+}
+
+/* Keys returns a tuple of keys in sorted order. */
+func (d *SortedDict) Keys() (tuple.TupleInterface, error) {
+	return tuple.MakeTupleFromValues(d.keys()...)
+}
+
+/* Values returns a tuple of values in sorted order of their keys. */
+func (d *SortedDict) Values() (tuple.TupleInterface, error) {
+	values := make([]interface{}, 0, d.Length())
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return tuple.MakeTupleFromValues(values...)
+}
+
+/* Items returns a tuple of key/value pairs in sorted order. */
+func (d *SortedDict) Items() (tuple.TupleInterface, error) {
+	items := make([]interface{}, 0, d.Length())
+	var err error
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		var item tuple.TupleInterface
+		item, err = tuple.MakeTupleFromValues(key, value)
+		items = append(items, item)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tuple.MakeTupleFromValues(items...)
+}
+
+/* Copy creates a copy of the current DictInterface, ordered by the same comparison function. */
+func (d *SortedDict) Copy() (DictInterface, error) {
+	output := &SortedDict{compare: d.compare}
+	output.Init()
+	var err error
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		_, err = output.tree.Put(key, value)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* String returns a string representation of the dict, in sorted order. */
+func (d *SortedDict) String() string {
+	output := "{"
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		output += fmt.Sprintf("(%v %v) ", key, value)
+		return true
+	})
+	output = strings.Trim(output, " ") + "}"
+	return output
+}
+
+/* First returns the smallest key in the dict, and false if the dict is empty. */
+func (d *SortedDict) First() (interface{}, bool) {
+	key, _, ok := d.tree.Min()
+	return key, ok
+}
+
+/* Last returns the largest key in the dict, and false if the dict is empty. */
+func (d *SortedDict) Last() (interface{}, bool) {
+	key, _, ok := d.tree.Max()
+	return key, ok
+}
+
+/* Floor returns the largest key in the dict which is less than or equal to the key, and false if there is none. */
+func (d *SortedDict) Floor(key interface{}) (interface{}, bool, error) {
+	return d.tree.Floor(key)
+}
+
+/* Ceiling returns the smallest key in the dict which is greater than or equal to the key, and false if there is none. */
+func (d *SortedDict) Ceiling(key interface{}) (interface{}, bool, error) {
+	return d.tree.Ceiling(key)
+}
+
+/* RangeKeys returns a tuple of the keys in the dict which are greater than or equal to lo and less than hi, in sorted order. */
+func (d *SortedDict) RangeKeys(lo interface{}, hi interface{}) (tuple.TupleInterface, error) {
+	keys := make([]interface{}, 0)
+	var errc error
+	err := d.tree.AscendFrom(lo, func(key interface{}, value interface{}) bool {
+		var c int
+		if c, errc = d.compare(key, hi); errc != nil || c >= 0 {
+			return false
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if err == nil {
+		err = errc
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tuple.MakeTupleFromValues(keys...)
+}
+
+/* Rank returns the number of keys in the dict which are less than the key, which is the index of the key if it is in the dict. */
+func (d *SortedDict) Rank(key interface{}) (int, error) {
+	return d.tree.Rank(key)
+}
+
+/* Select returns the key at index i in sorted order. Negative indexes count from the end of the dict. */
+func (d *SortedDict) Select(i int) (interface{}, error) {
+	j, ok := helpers.ElementIndex(i, d.tree.Len())
+	if !ok {
+		return nil, &errs.IndexError{Index: i, Length: d.tree.Len(), Structure: "dict"}
+	}
+	key, _, _ := d.tree.Select(j)
+	return key, nil
+}
+
+/* keys returns the keys in the dict in sorted order. */
+func (d *SortedDict) keys() []interface{} {
+	keys := make([]interface{}, 0, d.Length())
+	d.tree.Ascend(func(key interface{}, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+/* Init initializes the dict. */
+func (d *SortedDict) Init() {
+	if d.compare == nil {
+		d.compare = order.Compare
+	}
+	d.tree = tree.New(d.compare)
+}
+
+/* MakeSortedDict initializes a new dict ordered by order.Compare using an Iterable. Every even-indexed element is a key and odd-indexed element is a value. */
+func MakeSortedDict(it ...iterable.Iterable) (*SortedDict, error) {
+	return MakeSortedDictWithCompare(order.Compare, it...)
+}
+
+/* MakeSortedDictWithCompare initializes a new dict ordered by the comparison function, which returns -1, 0 or 1 as a is less than, equal to or greater than b. */
+func MakeSortedDictWithCompare(compare func(a interface{}, b interface{}) (int, error), it ...iterable.Iterable) (*SortedDict, error) {
+	output := &SortedDict{compare: compare}
+	output.Init()
+	if len(it) > 0 {
+		i := 0
+		var key interface{}
+		var err error
+		iterable.ForEach(it[0], func(v interface{}) bool {
+			if i%2 == 0 {
+				key = v
+			} else {
+				err = output.Set(key, v)
+			}
+			i += 1
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
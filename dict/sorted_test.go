@@ -0,0 +1,199 @@
+package dict
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dynago/dg/codec"
+)
+
+func TestSortedDict(t *testing.T) {
+	d, err := MakeSortedDict(&dictTester{a: []interface{}{"c", 3, "a", 1, "b", 2}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if ok := printChecker(d.String(), []string{"(a 1)", "(b 2)", "(c 3)"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	if err := d.Set("a", 4); err != nil {
+		t.Error(err)
+	} else if value, err := d.Get("a"); err != nil || value != 4 {
+		t.Fatalf("Got %v, was expecting 4", value)
+	}
+
+	if err := d.Set(1, "one"); err != nil {
+		t.Error(err)
+	} else if keys, err := d.Keys(); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(keys.String(), []string{"1", "a", "b", "c"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", keys.String())
+	}
+
+	if ok, err := d.Contains(1.0); err != nil || !ok {
+		t.Fatal("Expected 1.0 to be the same key as 1")
+	}
+
+	if err := d.Set([]int{1}, 1); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+
+	if key, value, err := d.Pop(); err != nil || key != "c" || value != 3 {
+		t.Fatalf("Got %v and %v, was expecting c and 3", key, value)
+	}
+
+	if err := d.Remove(1); err != nil {
+		t.Error(err)
+	} else if items, err := d.Items(); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(items.String(), []string{"(a 4)", "(b 2)"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", items.String())
+	}
+
+	other, _ := MakeDictFromKeyValues([]interface{}{"b", "a"}, []interface{}{2, 4})
+	if ok, err := d.Equals(other); err != nil || !ok {
+		t.Fatal("Expected dicts with the same items to be equal")
+	}
+
+	c, err := d.Copy()
+	if err != nil {
+		t.Error(err)
+	} else if err := d.Clear(); err != nil {
+		t.Error(err)
+	} else if d.Length() != 0 || c.Length() != 2 {
+		t.Fatalf("Got lengths %d and %d, was expecting 0 and 2", d.Length(), c.Length())
+	}
+}
+
+func TestSortedDictQueries(t *testing.T) {
+	d, err := MakeSortedDict()
+	if err != nil {
+		t.Error(err)
+	}
+	for _, key := range []int{50, 10, 40, 20, 30} {
+		d.Set(key, key*2)
+	}
+
+	if key, ok := d.First(); !ok || key != 10 {
+		t.Fatalf("Got %v, was expecting 10", key)
+	}
+	if key, ok := d.Last(); !ok || key != 50 {
+		t.Fatalf("Got %v, was expecting 50", key)
+	}
+	if key, ok, err := d.Floor(35); err != nil || !ok || key != 30 {
+		t.Fatalf("Got %v, was expecting 30", key)
+	}
+	if key, ok, err := d.Ceiling(35.5); err != nil || !ok || key != 40 {
+		t.Fatalf("Got %v, was expecting 40", key)
+	}
+	if _, ok, err := d.Ceiling(51); err != nil || ok {
+		t.Fatal("Expected no ceiling above the largest key")
+	}
+
+	if keys, err := d.RangeKeys(20, 50); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(keys.String(), []string{"20", "30", "40"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", keys.String())
+	}
+	if keys, err := d.RangeKeys(15, 16); err != nil {
+		t.Error(err)
+	} else if keys.Length() != 0 {
+		t.Fatalf("Got %s, was expecting no keys", keys.String())
+	}
+	if _, err := d.RangeKeys(10, []int{1}); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+
+	if rank, err := d.Rank(40); err != nil || rank != 3 {
+		t.Fatalf("Got %d, was expecting 3", rank)
+	}
+	if key, err := d.Select(1); err != nil || key != 20 {
+		t.Fatalf("Got %v, was expecting 20", key)
+	}
+	if key, err := d.Select(-1); err != nil || key != 50 {
+		t.Fatalf("Got %v, was expecting 50", key)
+	}
+	if _, err := d.Select(5); err == nil {
+		t.Fatal("Expected index error")
+	}
+
+	e, _ := MakeSortedDict()
+	if _, ok := e.First(); ok {
+		t.Fatal("Expected no first key in an empty dict")
+	}
+	if key, value, err := e.Pop(); !errors.Is(err, ErrEmpty) || key != nil || value != nil {
+		t.Fatal("Expected ErrEmpty from popping an empty dict")
+	}
+}
+
+func TestSortedDictCompare(t *testing.T) {
+	caseless := func(a interface{}, b interface{}) (int, error) {
+		return strings.Compare(strings.ToLower(a.(string)), strings.ToLower(b.(string))), nil
+	}
+	d, err := MakeSortedDictWithCompare(caseless)
+	if err != nil {
+		t.Error(err)
+	}
+	d.Set("b", 1)
+	d.Set("A", 2)
+	d.Set("a", 3)
+	if ok := printChecker(d.String(), []string{"(A 3)", "(b 1)"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", d.String())
+	}
+
+	c, _ := d.Copy()
+	if err := c.Set("B", 4); err != nil {
+		t.Error(err)
+	} else if c.Length() != 2 {
+		t.Fatalf("Got %s, was expecting the copy to keep the comparison", c.String())
+	}
+}
+
+func TestSortedDictEncoding(t *testing.T) {
+	d, _ := MakeSortedDict(&dictTester{a: []interface{}{"b", 1, "a", 2}})
+
+	if b, err := json.Marshal(d); err != nil {
+		t.Error(err)
+	} else if string(b) != `{"a":2,"b":1}` {
+		t.Fatalf("Got %s, which was unexpected", b)
+	}
+
+	s := new(SortedDict)
+	if err := json.Unmarshal([]byte(`{"z":1,"y":2}`), s); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"(y 2)", "(z 1)"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	b, err := codec.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := codec.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if o, ok := v.(*SortedDict); !ok || !o.EqualTo(d) {
+		t.Fatalf("Got %v, which was unexpected", v)
+	}
+	plain := new(Dict)
+	if err := plain.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if !plain.EqualTo(d) {
+		t.Fatalf("Got %s, which was unexpected", plain.String())
+	}
+
+	var buf bytes.Buffer
+	var out interface{}
+	var in interface{} = d
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Error(err)
+	} else if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Error(err)
+	} else if o, ok := out.(*SortedDict); !ok || !o.EqualTo(d) {
+		t.Fatalf("Got %v, which was unexpected", out)
+	}
+}
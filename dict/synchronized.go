@@ -0,0 +1,267 @@
+package dict
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/tuple"
+)
+
+// SyncDict is a dict which is safe for concurrent use. Iteration works over a snapshot of the dict.
+type SyncDict struct {
+	mu   sync.RWMutex
+	dict DictInterface
+}
+
+/* Length returns the number of elements in dict. */
+func (d *SyncDict) Length() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Length()
+}
+
+/* Iterate returns the next key in a snapshot of the dict. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (d *SyncDict) Iterate() <-chan interface{} {
+	return iterable.Channel(d)
+}
+
+/* ForEach calls the function on each key in a snapshot of the dict until it returns false. */
+func (d *SyncDict) ForEach(fn func(interface{}) bool) {
+	iter := d.Iterator()
+	defer iter.Close()
+	for iter.Next() {
+		if !fn(iter.Value()) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the keys in dict. */
+func (d *SyncDict) Iterator() iterable.Iterator {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Iterator()
+}
+
+/* Remove removes element from the dict. */
+func (d *SyncDict) Remove(key interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Remove(key)
+}
+
+/* Get returns the value with given key. A DefaultDict sets missing keys on Get, so it is read under the write lock. */
+func (d *SyncDict) Get(key interface{}) (interface{}, error) {
+	d.mu.RLock()
+	if _, ok := d.dict.(*DefaultDict); !ok {
+		defer d.mu.RUnlock()
+		return d.dict.Get(key)
+	}
+	d.mu.RUnlock()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Get(key)
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. */
+func (d *SyncDict) Lookup(key interface{}) (interface{}, bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Lookup(key)
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. */
+func (d *SyncDict) GetOr(key interface{}, def interface{}) (interface{}, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.GetOr(key, def)
+}
+
+/* Set sets the value at given key to given value. */
+func (d *SyncDict) Set(key interface{}, value interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Set(key, value)
+}
+
+/* Combine updates the dict, adding elements from the other dict. Old values are replaced with new. */
+func (d *SyncDict) Combine(other DictInterface) error {
+	other, err := unwrapDict(other)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Combine(other)
+}
+
+/* PopKey pops and returns an arbitrary key from the dict. */
+func (d *SyncDict) PopKey() (interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.PopKey()
+}
+
+/* PopValue pops and returns an arbitrary value from the dict. */
+func (d *SyncDict) PopValue() (interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.PopValue()
+}
+
+/* Pop pops and returns an item from the dict; the most recently inserted one if the wrapped dict keeps insertion order. */
+func (d *SyncDict) Pop() (interface{}, interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Pop()
+}
+
+/* PopFirst pops and returns the least recently inserted item from the dict. It returns an error if the wrapped dict does not keep insertion order. */
+func (d *SyncDict) PopFirst() (interface{}, interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	o, err := d.ordered()
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.PopFirst()
+}
+
+/* PopLast pops and returns the most recently inserted item from the dict. It returns an error if the wrapped dict does not keep insertion order. */
+func (d *SyncDict) PopLast() (interface{}, interface{}, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	o, err := d.ordered()
+	if err != nil {
+		return nil, nil, err
+	}
+	return o.PopLast()
+}
+
+/* MoveToEnd moves the key to the end of the dict. It returns an error if the wrapped dict does not keep insertion order. */
+func (d *SyncDict) MoveToEnd(key interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	o, err := d.ordered()
+	if err != nil {
+		return err
+	}
+	return o.MoveToEnd(key)
+}
+
+/* Clear clears all elements from the dict. */
+func (d *SyncDict) Clear() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dict.Clear()
+}
+
+/* Contains tests for membership in the dict. */
+func (d *SyncDict) Contains(key interface{}) (bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Contains(key)
+}
+
+/* Equals returns true if the dict has all elements in common with the other dict. */
+func (d *SyncDict) Equals(other DictInterface) (bool, error) {
+	other, err := unwrapDict(other)
+	if err != nil {
+		return false, err
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Equals(other)
+}
+
+/* HashKey returns the bytes which identify a snapshot of the dict. The order of the keys does not change the key. */
+func (d *SyncDict) HashKey() ([]byte, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return hashKey(d.dict)
+}
+
+/* EqualTo returns true if the other value is a dict with equal keys and values. */
+func (d *SyncDict) EqualTo(other interface{}) bool {
+	o, ok := other.(DictInterface)
+	if !ok {
+		return false
+	}
+	equals, err := d.Equals(o)
+	return err == nil && equals
+}
+
+/* Keys returns a tuple of keys. */
+func (d *SyncDict) Keys() (tuple.TupleInterface, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Keys()
+}
+
+/* Values returns a tuple of values. */
+func (d *SyncDict) Values() (tuple.TupleInterface, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Values()
+}
+
+/* Items returns a tuple of key/value pairs. */
+func (d *SyncDict) Items() (tuple.TupleInterface, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.Items()
+}
+
+/* Copy creates a synchronized copy of the current dict. */
+func (d *SyncDict) Copy() (DictInterface, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	output, err := d.dict.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return Synchronized(output), nil
+}
+
+/* String returns a string representation of the dict. */
+func (d *SyncDict) String() string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.dict.String()
+}
+
+/* Init initializes the dict. */
+func (d *SyncDict) Init() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.dict == nil {
+		d.dict = new(Dict)
+	}
+	d.dict.Init()
+}
+
+/* ordered returns the wrapped dict if it keeps insertion order. */
+func (d *SyncDict) ordered() (OrderedDictInterface, error) {
+	o, ok := d.dict.(OrderedDictInterface)
+	if !ok {
+		return nil, fmt.Errorf("Dict of type %T does not keep insertion order", d.dict)
+	}
+	return o, nil
+}
+
+/* unwrapDict returns an unsynchronized copy of the dict if it is synchronized, so that it can be read while another lock is held. */
+func unwrapDict(d DictInterface) (DictInterface, error) {
+	s, ok := d.(*SyncDict)
+	if !ok {
+		return d, nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.dict.Copy()
+}
+
+/* Synchronized returns a dict which guards the given dict with a read/write lock. The given dict should not be used directly afterwards. */
+func Synchronized(d DictInterface) DictInterface {
+	return &SyncDict{dict: d}
+}
@@ -0,0 +1,90 @@
+package dict
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronizedConcurrent(t *testing.T) {
+	d, err := MakeDict()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s := Synchronized(d)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if err := s.Set(i*50+j, j); err != nil {
+					t.Error(err)
+				}
+				s.ForEach(func(key interface{}) bool {
+					_, err := s.Get(key)
+					return err == nil
+				})
+				s.Contains(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if s.Length() != 400 {
+		t.Errorf("Expected 400 elements, got %d", s.Length())
+	}
+}
+
+func TestSynchronizedDefaultDict(t *testing.T) {
+	d, _ := MakeDefaultDict(func() interface{} { return 0 })
+	s := Synchronized(d)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if value, err := s.Get(j); err != nil {
+					t.Error(err)
+				} else if value != 0 {
+					t.Errorf("Got %v, was expecting the value from the factory", value)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Length() != 50 {
+		t.Errorf("Expected 50 elements, got %d", s.Length())
+	}
+}
+
+func TestSynchronizedSnapshot(t *testing.T) {
+	d, _ := MakeDictFromKeyValues([]interface{}{1, 2, 3}, []interface{}{1, 2, 3})
+	s := Synchronized(d)
+	count := 0
+	s.ForEach(func(key interface{}) bool {
+		count++
+		if err := s.Remove(key); err != nil {
+			t.Error(err)
+		}
+		return true
+	})
+	if count != 3 {
+		t.Errorf("Expected to visit 3 keys, visited %d", count)
+	} else if s.Length() != 0 {
+		t.Errorf("Expected empty dict, got %v", s)
+	}
+}
+
+func TestSynchronizedSelf(t *testing.T) {
+	d, _ := MakeDictFromKeyValues([]interface{}{1, 2}, []interface{}{"a", "b"})
+	s := Synchronized(d)
+	if err := s.Combine(s); err != nil {
+		t.Error(err)
+	} else if equals, err := s.Equals(s); err != nil || !equals {
+		t.Error("Synchronized dict is not equal to itself")
+	} else if c, err := s.Copy(); err != nil {
+		t.Error(err)
+	} else if _, ok := c.(*SyncDict); !ok {
+		t.Errorf("Expected copy to be synchronized, got %T", c)
+	}
+}
@@ -1,2 +1,16 @@
 // Package dynago implements functions to create dictionaries, list, sets and tuple using any type.
 package dynago
+
+import (
+	"github.com/dynago/dg/internal/jsonvalue"
+
+	// The structures register how to decode themselves.
+	_ "github.com/dynago/dg/dict"
+	_ "github.com/dynago/dg/list"
+	_ "github.com/dynago/dg/tuple"
+)
+
+/* FromJSON decodes any JSON document. Objects become dicts and arrays become lists. Numbers become an int if they are integers which fit, and a float64 otherwise. */
+func FromJSON(data []byte) (interface{}, error) {
+	return jsonvalue.Decode(data, false)
+}
@@ -0,0 +1,34 @@
+package dynago
+
+import (
+	"testing"
+
+	"github.com/dynago/dg/dict"
+	"github.com/dynago/dg/list"
+)
+
+func TestFromJSON(t *testing.T) {
+	value, err := FromJSON([]byte(`{"a": [1, {"b": 2}], "c": 9007199254740993}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	d, ok := value.(dict.DictInterface)
+	if !ok {
+		t.Fatalf("Expected dict, got %T", value)
+	}
+	a, _ := d.Get("a")
+	if l, ok := a.(list.ListInterface); !ok || l.Length() != 2 {
+		t.Errorf("Expected list of length 2, got %v", a)
+	} else if inner, _ := l.Get(1); inner == nil {
+		t.Error("Expected nested dict")
+	} else if _, ok := inner.(dict.DictInterface); !ok {
+		t.Errorf("Expected nested dict, got %T", inner)
+	} else if c, _ := d.Get("c"); c != 9007199254740993 {
+		t.Errorf("Expected exact integer, got %v", c)
+	}
+	if v, err := FromJSON([]byte(`1.5`)); err != nil || v != 1.5 {
+		t.Errorf("Expected 1.5, got %v", v)
+	} else if _, err := FromJSON([]byte(`[1] [2]`)); err == nil {
+		t.Error("Expected error for trailing data")
+	}
+}
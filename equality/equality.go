@@ -0,0 +1,150 @@
+// Package equality implements the deep structural equality used to compare values in dg structures.
+package equality
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Equaler is the interface implemented by types which decide for themselves whether they are equal to another value.
+type Equaler interface {
+	/* Return true if the value is equal to the other value. */
+	EqualTo(interface{}) bool
+}
+
+// Comparator is a custom comparison for values the caller does not control. It returns whether the values are equal, and false for ok if it does not handle them.
+type Comparator func(a interface{}, b interface{}) (equal bool, ok bool)
+
+var (
+	comparatorsMu sync.RWMutex
+	comparators   []Comparator
+)
+
+/* RegisterComparator adds a Comparator which is consulted before any other comparison. Comparators are consulted in the order they are registered. */
+func RegisterComparator(c Comparator) {
+	comparatorsMu.Lock()
+	defer comparatorsMu.Unlock()
+	comparators = append(comparators, c)
+}
+
+/* Equal returns true if the values are deeply equal. Equalers (including dg structures) compare themselves, and slices, arrays, maps, structs and pointers are compared element by element. Values of different types are never equal, and funcs are only equal if both are nil. */
+func Equal(a interface{}, b interface{}) bool {
+	return equal(a, b, make(map[visit]bool))
+}
+
+// visit records a pair of pointers being compared, to stop at cycles.
+type visit struct {
+	a   uintptr
+	b   uintptr
+	typ reflect.Type
+}
+
+func equal(a interface{}, b interface{}, visited map[visit]bool) bool {
+	comparatorsMu.RLock()
+	for _, c := range comparators {
+		if eq, ok := c(a, b); ok {
+			comparatorsMu.RUnlock()
+			return eq
+		}
+	}
+	comparatorsMu.RUnlock()
+
+	if e, ok := a.(Equaler); ok {
+		return e.EqualTo(b)
+	}
+	if e, ok := b.(Equaler); ok {
+		return e.EqualTo(a)
+	}
+	if a == nil || b == nil {
+		return a == b
+	}
+	return equalValue(reflect.ValueOf(a), reflect.ValueOf(b), visited)
+}
+
+func equalValue(a reflect.Value, b reflect.Value, visited map[visit]bool) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+	if a.CanInterface() && b.CanInterface() {
+		if _, ok := a.Interface().(Equaler); ok {
+			return equal(a.Interface(), b.Interface(), visited)
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() && (a.Kind() != reflect.Slice || a.Len() == b.Len()) {
+			return true
+		}
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		return equalValue(a.Elem(), b.Elem(), visited)
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Elem().CanInterface() && b.Elem().CanInterface() {
+			return equal(a.Elem().Interface(), b.Elem().Interface(), visited)
+		}
+		return equalValue(a.Elem(), b.Elem(), visited)
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !equalValue(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			bv := b.MapIndex(iter.Key())
+			if !bv.IsValid() || !equalValue(iter.Value(), bv, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !equalValue(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	}
+	return false
+}
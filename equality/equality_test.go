@@ -0,0 +1,104 @@
+package equality
+
+import (
+	"strings"
+	"testing"
+)
+
+type equalerTester struct {
+	name string
+}
+
+func (e equalerTester) EqualTo(other interface{}) bool {
+	o, ok := other.(equalerTester)
+	return ok && strings.EqualFold(o.name, e.name)
+}
+
+func TestEqualBasic(t *testing.T) {
+	if !Equal(1, 1) {
+		t.Fatal("1 and 1 should be equal, but they are not")
+	}
+	if Equal(1, 1.0) {
+		t.Fatal("1 and 1.0 should not be equal, but they are")
+	}
+	if !Equal(nil, nil) {
+		t.Fatal("nil and nil should be equal, but they are not")
+	}
+	if Equal(nil, 0) {
+		t.Fatal("nil and 0 should not be equal, but they are")
+	}
+	if !Equal("hello", "hello") {
+		t.Fatal("hello and hello should be equal, but they are not")
+	}
+}
+
+func TestEqualStructural(t *testing.T) {
+	type tester struct {
+		a []int
+		b map[string]interface{}
+	}
+
+	if !Equal([]int{1, 2}, []int{1, 2}) {
+		t.Fatal("[1 2] and [1 2] should be equal, but they are not")
+	}
+	if Equal([]int{1, 2}, []int{2, 1}) {
+		t.Fatal("[1 2] and [2 1] should not be equal, but they are")
+	}
+	if !Equal(map[string]interface{}{"a": []int{1}}, map[string]interface{}{"a": []int{1}}) {
+		t.Fatal("Maps with equal values should be equal, but they are not")
+	}
+	if Equal(map[string]int{"a": 1}, map[string]int{"b": 1}) {
+		t.Fatal("Maps with different keys should not be equal, but they are")
+	}
+
+	s1 := tester{[]int{1}, map[string]interface{}{"x": 1}}
+	s2 := tester{[]int{1}, map[string]interface{}{"x": 1}}
+	s3 := tester{[]int{1}, map[string]interface{}{"x": 2}}
+	if !Equal(s1, s2) {
+		t.Fatal("s1 and s2 should be equal, but they are not")
+	}
+	if Equal(s1, s3) {
+		t.Fatal("s1 and s3 should not be equal, but they are")
+	}
+	if !Equal(&s1, &s2) {
+		t.Fatal("&s1 and &s2 should be equal, but they are not")
+	}
+
+	f := func() {}
+	if Equal(f, f) {
+		t.Fatal("Funcs should not be equal, but they are")
+	}
+}
+
+func TestEqualEqualer(t *testing.T) {
+	if !Equal(equalerTester{"A"}, equalerTester{"a"}) {
+		t.Fatal("Equalers should decide equality, but they did not")
+	}
+	if !Equal([]interface{}{equalerTester{"A"}}, []interface{}{equalerTester{"a"}}) {
+		t.Fatal("Nested equalers should decide equality, but they did not")
+	}
+}
+
+func TestRegisterComparator(t *testing.T) {
+	type caseless string
+
+	if Equal(caseless("A"), caseless("a")) {
+		t.Fatal("A and a should not be equal before registering a comparator, but they are")
+	}
+
+	RegisterComparator(func(a interface{}, b interface{}) (bool, bool) {
+		sa, ok1 := a.(caseless)
+		sb, ok2 := b.(caseless)
+		if !ok1 || !ok2 {
+			return false, false
+		}
+		return strings.EqualFold(string(sa), string(sb)), true
+	})
+
+	if !Equal(caseless("A"), caseless("a")) {
+		t.Fatal("A and a should be equal after registering a comparator, but they are not")
+	}
+	if !Equal(1, 1) {
+		t.Fatal("The comparator should not change other types, but it did")
+	}
+}
@@ -0,0 +1,153 @@
+// Package errs defines the errors returned by dg structures. Each error type matches its sentinel with errors.Is, and can be unpacked with errors.As.
+package errs
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrIndexOutOfRange is matched by every IndexError.
+	ErrIndexOutOfRange = errors.New("Index out of range")
+	// ErrKeyNotFound is matched by every KeyError.
+	ErrKeyNotFound = errors.New("Key not found")
+	// ErrUnhashable is matched by every HashError.
+	ErrUnhashable = errors.New("Value cannot be hashed")
+	// ErrEmpty is matched by every EmptyError.
+	ErrEmpty = errors.New("Structure is empty")
+	// ErrLengthMismatch is matched by every LengthMismatchError.
+	ErrLengthMismatch = errors.New("Lengths do not match")
+	// ErrWrongType is matched by every TypeError.
+	ErrWrongType = errors.New("Value is of the wrong type")
+	// ErrReadOnly is matched by every ReadOnlyError.
+	ErrReadOnly = errors.New("Structure is read-only")
+)
+
+// IndexError is the error returned when an index is out of range of a structure.
+type IndexError struct {
+	Index     int
+	Length    int
+	Structure string
+}
+
+/* Error returns a description of the index and the length of the structure. */
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("Index %d out of range of %s of length %d", e.Index, e.Structure, e.Length)
+}
+
+/* Is returns true if the target is ErrIndexOutOfRange. */
+func (e *IndexError) Is(target error) bool {
+	return target == ErrIndexOutOfRange
+}
+
+// KeyError is the error returned when a key which must be in a structure is not.
+type KeyError struct {
+	Key       interface{}
+	Structure string
+}
+
+/* Error returns a description of the missing key. */
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("Key %v not in %s", e.Key, e.Structure)
+}
+
+/* Is returns true if the target is ErrKeyNotFound. */
+func (e *KeyError) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+// HashError is the error returned when a value cannot be hashed. It wraps the error from the Hasher, such as the failure to marshal the value.
+type HashError struct {
+	Value interface{}
+	Err   error
+}
+
+/* Error returns a description of the value and why it could not be hashed. */
+func (e *HashError) Error() string {
+	return fmt.Sprintf("Cannot hash value of type %T: %v", e.Value, e.Err)
+}
+
+/* Unwrap returns the error from the Hasher. */
+func (e *HashError) Unwrap() error {
+	return e.Err
+}
+
+/* Is returns true if the target is ErrUnhashable. */
+func (e *HashError) Is(target error) bool {
+	return target == ErrUnhashable
+}
+
+// EmptyError is the error returned when an operation needs an element but the structure is empty. Op describes the operation, such as "pop from".
+type EmptyError struct {
+	Op        string
+	Structure string
+}
+
+/* Error returns a description of the operation on the empty structure. */
+func (e *EmptyError) Error() string {
+	return fmt.Sprintf("Cannot %s empty %s", e.Op, e.Structure)
+}
+
+/* Is returns true if the target is ErrEmpty. */
+func (e *EmptyError) Is(target error) bool {
+	return target == ErrEmpty
+}
+
+// LengthMismatchError is the error returned when a number of values does not match the number needed, such as a dict made from different numbers of keys and values, an item which is not a (key, value) pair, or a slice assigned the wrong number of values. Of describes what has the wrong length.
+type LengthMismatchError struct {
+	Length   int
+	Expected int
+	Of       string
+}
+
+/* Error returns a description of the length and the length needed. */
+func (e *LengthMismatchError) Error() string {
+	return fmt.Sprintf("Length %d of %s does not match expected length %d", e.Length, e.Of, e.Expected)
+}
+
+/* Is returns true if the target is ErrLengthMismatch. */
+func (e *LengthMismatchError) Is(target error) bool {
+	return target == ErrLengthMismatch
+}
+
+// ReadOnlyError is the error returned when modifying a structure which cannot be modified, such as a frozen set. Op describes the operation, such as "add to".
+type ReadOnlyError struct {
+	Op        string
+	Structure string
+}
+
+/* Error returns a description of the operation on the read-only structure. */
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("Cannot %s %s", e.Op, e.Structure)
+}
+
+/* Is returns true if the target is ErrReadOnly. */
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// TypeError is the error returned when a value is not of the type a structure needs, such as a count in a counter which is not an integer. Key is the key the value belongs to.
+type TypeError struct {
+	Value    interface{}
+	Key      interface{}
+	Expected string
+}
+
+/* Error returns a description of the value and the type it should have. */
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("Value %v of %v is of type %T, not %s", e.Value, e.Key, e.Value, e.Expected)
+}
+
+/* Is returns true if the target is ErrWrongType. */
+func (e *TypeError) Is(target error) bool {
+	return target == ErrWrongType
+}
+
+/* Hash returns the error as a HashError for the value, unless it is nil or already wraps a HashError. */
+func Hash(value interface{}, err error) error {
+	var hashErr *HashError
+	if err == nil || errors.As(err, &hashErr) {
+		return err
+	}
+	return &HashError{Value: value, Err: err}
+}
@@ -0,0 +1,46 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		err      error
+		sentinel error
+		message  string
+	}{
+		{&IndexError{Index: 5, Length: 3, Structure: "list"}, ErrIndexOutOfRange, "Index 5 out of range of list of length 3"},
+		{&KeyError{Key: "a", Structure: "dict"}, ErrKeyNotFound, "Key a not in dict"},
+		{&HashError{Value: nil, Err: fmt.Errorf("Cannot generate SHA from nil")}, ErrUnhashable, "Cannot hash value of type <nil>: Cannot generate SHA from nil"},
+		{&EmptyError{Op: "pop from", Structure: "deque"}, ErrEmpty, "Cannot pop from empty deque"},
+		{&LengthMismatchError{Length: 1, Expected: 2, Of: "values"}, ErrLengthMismatch, "Length 1 of values does not match expected length 2"},
+		{&ReadOnlyError{Op: "add to", Structure: "frozen set"}, ErrReadOnly, "Cannot add to frozen set"},
+		{&TypeError{Value: 1.5, Key: "a", Expected: "an integer"}, ErrWrongType, "Value 1.5 of a is of type float64, not an integer"},
+	}
+	for _, test := range tests {
+		wrapped := fmt.Errorf("Wrapped: %w", test.err)
+		if test.err.Error() != test.message {
+			t.Errorf("Got %q, was expecting %q", test.err.Error(), test.message)
+		} else if !errors.Is(wrapped, test.sentinel) {
+			t.Errorf("Expected %v to match %v", wrapped, test.sentinel)
+		} else if errors.Is(test.err, errors.New(test.sentinel.Error())) {
+			t.Errorf("Expected %v to match only its own sentinel", test.err)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	cause := fmt.Errorf("Cannot marshal")
+	err := Hash(1, cause)
+	var hashErr *HashError
+	if !errors.As(err, &hashErr) || hashErr.Value != 1 || !errors.Is(err, cause) {
+		t.Fatalf("Got %v, was expecting a HashError wrapping the cause", err)
+	} else if again := Hash(2, fmt.Errorf("Hashing: %w", err)); !errors.As(again, &hashErr) || hashErr.Value != 1 {
+		t.Fatalf("Got %v, was expecting the HashError not to be wrapped twice", again)
+	} else if Hash(1, nil) != nil {
+		t.Fatal("Expected nil error to stay nil")
+	}
+}
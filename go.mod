@@ -1,3 +1,3 @@
 module github.com/dynago/dg
 
-go 1.14
+go 1.18
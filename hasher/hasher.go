@@ -0,0 +1,55 @@
+// Package hasher implements the hashing used to identify dict keys and set elements.
+package hasher
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/internal/helpers"
+)
+
+// Hasher is the interface which defines how a value is turned into a hash.
+type Hasher interface {
+	/* Return a string hash of the value. Equal values must return equal hashes. */
+	Hash(interface{}) (string, error)
+}
+
+// Hashable is the interface which lets a type control its own identity in dicts and sets.
+type Hashable interface {
+	/* Return the bytes which identify the value. Equal values must return equal bytes. */
+	HashKey() ([]byte, error)
+	/* Return true if the value is equal to the other value. */
+	EqualTo(interface{}) bool
+}
+
+// SHA is the default Hasher. It hashes the JSON encoding and formatted representation of a value using SHA-1.
+type SHA struct{}
+
+/* Hash returns a string hash based on the given value. */
+func (SHA) Hash(value interface{}) (string, error) {
+	return helpers.GetSHA(value)
+}
+
+/* Default returns the Hasher used by structures that are not given one. */
+func Default() Hasher {
+	return SHA{}
+}
+
+/* Hash returns the hash of the value. Hashable values are hashed from their HashKey, other values are hashed by the Hasher. Any error is returned as an *errs.HashError. */
+func Hash(h Hasher, value interface{}) (string, error) {
+	hv, ok := value.(Hashable)
+	if !ok {
+		hash, err := h.Hash(value)
+		return hash, errs.Hash(value, err)
+	}
+	key, err := hv.HashKey()
+	if err != nil {
+		return "", errs.Hash(value, err)
+	}
+	hash := sha1.New()
+	hash.Write([]byte(fmt.Sprintf("%T", value)))
+	hash.Write(key)
+	return base64.URLEncoding.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,356 @@
+// Package heap implements heaps, which are priority queues of values with priorities of any ordered type.
+package heap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/order"
+)
+
+// Item is a value in a heap with its priority. The item returned by Push is a handle for changing its priority or removing it.
+type Item struct {
+	value    interface{}
+	priority interface{}
+	heap     *Heap // heap holding the item, or nil once it is removed
+	index    int   // index of the item in the heap
+}
+
+/* Value returns the value of the item. */
+func (i *Item) Value() interface{} {
+	return i.value
+}
+
+/* Priority returns the priority of the item. */
+func (i *Item) Priority() interface{} {
+	return i.priority
+}
+
+// Heap is a binary heap ordered by a comparison function of priorities. The item with the smallest priority is at the top of a min heap, and the item with the largest priority is at the top of a max heap. Push, Pop, Update and Remove take O(log n) time.
+type Heap struct {
+	items   []*Item
+	compare func(a interface{}, b interface{}) (int, error)
+}
+
+/* Length returns the number of items in heap. */
+func (h *Heap) Length() int {
+	return len(h.items)
+}
+
+/* Iterate returns the next value in heap, in priority order. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (h *Heap) Iterate() <-chan interface{} {
+	return iterable.Channel(h)
+}
+
+/* ForEach calls the function on each value in a snapshot of the heap, in priority order, until it returns false. Visiting every value takes O(n log n) time. */
+func (h *Heap) ForEach(fn func(interface{}) bool) {
+	snapshot := h.copy()
+	for len(snapshot.items) > 0 {
+		value, _, err := snapshot.Pop()
+		if err != nil || !fn(value) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the values in heap, in priority order. */
+func (h *Heap) Iterator() iterable.Iterator {
+	values := make([]interface{}, 0, len(h.items))
+	h.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return iterable.FromSlice(values)
+}
+
+/* ForEachItem calls the function on each item in heap, in no particular order, until it returns false. The function must not change the heap. */
+func (h *Heap) ForEachItem(fn func(*Item) bool) {
+	for _, item := range h.items {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+/* Push adds the value with the priority, and returns its item. It returns an error if the priority cannot be compared with the priorities in the heap. */
+func (h *Heap) Push(value interface{}, priority interface{}) (*Item, error) {
+	if h.compare == nil {
+		h.Init()
+	}
+	item := &Item{value: value, priority: priority, heap: h, index: len(h.items)}
+	h.items = append(h.items, item)
+	if _, err := h.up(item.index); err != nil {
+		h.items = h.items[:len(h.items)-1]
+		return nil, err
+	}
+	return item, nil
+}
+
+/* Pop pops and returns the value and priority at the top of the heap. */
+func (h *Heap) Pop() (interface{}, interface{}, error) {
+	if len(h.items) == 0 {
+		return nil, nil, &errs.EmptyError{Op: "pop from", Structure: "heap"}
+	}
+	top := h.items[0]
+	if err := h.delete(0); err != nil {
+		return nil, nil, err
+	}
+	return top.value, top.priority, nil
+}
+
+/* Peek returns the value and priority at the top of the heap without removing them. */
+func (h *Heap) Peek() (interface{}, interface{}, error) {
+	if len(h.items) == 0 {
+		return nil, nil, &errs.EmptyError{Op: "peek at", Structure: "heap"}
+	}
+	return h.items[0].value, h.items[0].priority, nil
+}
+
+/* Update changes the priority of the item and moves it to its place in the heap. */
+func (h *Heap) Update(item *Item, priority interface{}) error {
+	if item == nil || item.heap != h {
+		return fmt.Errorf("Item is not in heap")
+	}
+	old := item.priority
+	item.priority = priority
+	moved, err := h.up(item.index)
+	if err == nil && !moved {
+		err = h.down(item, item.index, len(h.items))
+	}
+	if err != nil {
+		item.priority = old
+	}
+	return err
+}
+
+/* DecreaseKey changes the priority of the item to one which is no further from the top of the heap, so no greater in a min heap and no smaller in a max heap. */
+func (h *Heap) DecreaseKey(item *Item, priority interface{}) error {
+	if item == nil || item.heap != h {
+		return fmt.Errorf("Item is not in heap")
+	}
+	c, err := h.compare(priority, item.priority)
+	if err != nil {
+		return err
+	}
+	if c > 0 {
+		return fmt.Errorf("Priority %v is further from the top of the heap than %v", priority, item.priority)
+	}
+	old := item.priority
+	item.priority = priority
+	if _, err = h.up(item.index); err != nil {
+		item.priority = old
+	}
+	return err
+}
+
+/* Remove removes the item from the heap. */
+func (h *Heap) Remove(item *Item) error {
+	if item == nil || item.heap != h {
+		return fmt.Errorf("Item is not in heap")
+	}
+	return h.delete(item.index)
+}
+
+/* Merge adds copies of the items in the other heap, which is not changed. Items of the other heap remain handles for the other heap only. It takes O(n + m) time. */
+func (h *Heap) Merge(other HeapInterface) error {
+	if h.compare == nil {
+		h.Init()
+	}
+	saved := append([]*Item(nil), h.items...)
+	other.ForEachItem(func(item *Item) bool {
+		h.items = append(h.items, &Item{value: item.value, priority: item.priority, heap: h, index: len(h.items)})
+		return true
+	})
+	if err := h.heapify(); err != nil {
+		h.items = saved
+		for i, item := range h.items {
+			item.index = i
+		}
+		return err
+	}
+	return nil
+}
+
+/* Clear clears all items from the heap. Their handles can no longer be used with the heap. */
+func (h *Heap) Clear() error {
+	h.Init()
+	return nil
+}
+
+/* Copy creates a copy of the current HeapInterface ordered by the same comparison function. Items of the heap remain handles for the heap only. */
+func (h *Heap) Copy() (HeapInterface, error) {
+	return h.copy(), nil
+}
+
+/* String returns a string representation of the heap, with each value and its priority in priority order. */
+func (h *Heap) String() string {
+	output := "["
+	snapshot := h.copy()
+	for len(snapshot.items) > 0 {
+		value, priority, err := snapshot.Pop()
+		if err != nil {
+			break
+		}
+		output += fmt.Sprintf("(%v %v) ", value, priority)
+	}
+	output = strings.Trim(output, " ") + "]"
+	return output
+}
+
+/* copy returns a copy of the heap with new items. */
+func (h *Heap) copy() *Heap {
+	output := &Heap{compare: h.compare, items: make([]*Item, len(h.items))}
+	for i, item := range h.items {
+		output.items[i] = &Item{value: item.value, priority: item.priority, heap: output, index: i}
+	}
+	return output
+}
+
+/* less returns true if the first item belongs nearer the top of the heap than the second. */
+func (h *Heap) less(a *Item, b *Item) (bool, error) {
+	c, err := h.compare(a.priority, b.priority)
+	return c < 0, err
+}
+
+/* set places the item at index i. */
+func (h *Heap) set(i int, item *Item) {
+	h.items[i] = item
+	item.index = i
+}
+
+/* up moves the item at index i towards the top of the heap until its parent is no greater, and returns true if it moved. The heap is unchanged if a comparison fails. */
+func (h *Heap) up(i int) (bool, error) {
+	item := h.items[i]
+	j := i
+	for j > 0 {
+		parent := (j - 1) / 2
+		less, err := h.less(item, h.items[parent])
+		if err != nil {
+			return false, err
+		}
+		if !less {
+			break
+		}
+		j = parent
+	}
+	for k := i; k > j; k = (k - 1) / 2 {
+		h.set(k, h.items[(k-1)/2])
+	}
+	h.set(j, item)
+	return j != i, nil
+}
+
+/* down places the item at index i of the first n items, moving it away from the top of the heap until neither child is smaller. The heap is unchanged if a comparison fails. */
+func (h *Heap) down(item *Item, i int, n int) error {
+	path := make([]int, 0)
+	for j := i; ; {
+		child := 2*j + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n {
+			less, err := h.less(h.items[right], h.items[child])
+			if err != nil {
+				return err
+			}
+			if less {
+				child = right
+			}
+		}
+		less, err := h.less(h.items[child], item)
+		if err != nil {
+			return err
+		}
+		if !less {
+			break
+		}
+		path = append(path, child)
+		j = child
+	}
+	for _, child := range path {
+		h.set(i, h.items[child])
+		i = child
+	}
+	h.set(i, item)
+	return nil
+}
+
+/* delete removes the item at index i, replacing it with the last item. The heap is unchanged if a comparison fails. */
+func (h *Heap) delete(i int) error {
+	removed := h.items[i]
+	n := len(h.items) - 1
+	if i != n {
+		last := h.items[n]
+		h.items[i] = last
+		last.index = i
+		moved, err := h.up(i)
+		if err == nil && !moved {
+			err = h.down(last, i, n)
+		}
+		if err != nil {
+			h.set(i, removed)
+			h.set(n, last)
+			return err
+		}
+	}
+	h.items[n] = nil
+	h.items = h.items[:n]
+	removed.heap = nil
+	return nil
+}
+
+/* heapify restores the heap property over every item in O(n) time. The heap may be left out of order if a comparison fails. */
+func (h *Heap) heapify() error {
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		if err := h.down(h.items[i], i, len(h.items)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* Init initializes the heap, ordered by order.Compare as a min heap unless it has a comparison function. */
+func (h *Heap) Init() {
+	if h.compare == nil {
+		h.compare = order.Compare
+	}
+	for _, item := range h.items {
+		item.heap = nil
+	}
+	h.items = make([]*Item, 0)
+}
+
+/* reverse returns the comparison function which orders priorities the opposite way. */
+func reverse(compare func(a interface{}, b interface{}) (int, error)) func(a interface{}, b interface{}) (int, error) {
+	return func(a interface{}, b interface{}) (int, error) {
+		return compare(b, a)
+	}
+}
+
+/* MakeMinHeap initializes a new heap with the smallest priority at the top, ordered by order.Compare, using an Iterable. Each value is its own priority. */
+func MakeMinHeap(it ...iterable.Iterable) (HeapInterface, error) {
+	return MakeHeapWithCompare(order.Compare, it...)
+}
+
+/* MakeMaxHeap initializes a new heap with the largest priority at the top, ordered by order.Compare, using an Iterable. Each value is its own priority. */
+func MakeMaxHeap(it ...iterable.Iterable) (HeapInterface, error) {
+	return MakeHeapWithCompare(reverse(order.Compare), it...)
+}
+
+/* MakeHeapWithCompare initializes a new heap ordered by the comparison function, which returns -1, 0 or 1 as a is less than, equal to or greater than b. The item with the smallest priority is at the top. Each value of the Iterable is its own priority. */
+func MakeHeapWithCompare(compare func(a interface{}, b interface{}) (int, error), it ...iterable.Iterable) (HeapInterface, error) {
+	output := &Heap{compare: compare}
+	output.Init()
+	if len(it) > 0 {
+		iterable.ForEach(it[0], func(value interface{}) bool {
+			output.items = append(output.items, &Item{value: value, priority: value, heap: output, index: len(output.items)})
+			return true
+		})
+		if err := output.heapify(); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
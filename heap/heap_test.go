@@ -0,0 +1,210 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/order"
+)
+
+/* check verifies that every item is no nearer the top than its parent and knows its index. */
+func check(t *testing.T, h *Heap) {
+	for i, item := range h.items {
+		if item.index != i || item.heap != h {
+			t.Fatalf("Item %v has index %d, was expecting %d", item.value, item.index, i)
+		}
+		if i > 0 {
+			if less, _ := h.less(item, h.items[(i-1)/2]); less {
+				t.Fatalf("Item %v is nearer the top than its parent", item.value)
+			}
+		}
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	hi, _ := MakeMinHeap()
+	h := hi.(*Heap)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		if _, err := h.Push(i, r.Intn(50)); err != nil {
+			t.Fatal(err)
+		}
+		check(t, h)
+	}
+
+	if _, priority, err := h.Peek(); err != nil || priority != 0 {
+		t.Fatalf("Got %v, was expecting 0", priority)
+	}
+
+	last := -1
+	for h.Length() > 0 {
+		_, priority, err := h.Pop()
+		if err != nil {
+			t.Fatal(err)
+		} else if priority.(int) < last {
+			t.Fatalf("Got %v after %v", priority, last)
+		}
+		last = priority.(int)
+		check(t, h)
+	}
+
+	if _, _, err := h.Pop(); err == nil {
+		t.Fatal("Expected error popping from empty heap")
+	} else if _, _, err := h.Peek(); err == nil {
+		t.Fatal("Expected error peeking at empty heap")
+	}
+
+	if _, err := h.Push("a", 1); err != nil {
+		t.Error(err)
+	} else if _, err := h.Push("b", map[int]int{}); err == nil {
+		t.Fatal("Expected comparison error")
+	} else if h.Length() != 1 {
+		t.Fatalf("Got length %d, was expecting 1", h.Length())
+	}
+
+	var zero Heap
+	zero.Push("b", 2)
+	zero.Push("a", 1)
+	if value, _, err := zero.Pop(); err != nil || value != "a" {
+		t.Fatalf("Got %v, was expecting a", value)
+	}
+}
+
+func TestMaxHeap(t *testing.T) {
+	l, _ := list.MakeListFromValues(3, 1.5, 4, 1, 5)
+	h, err := MakeMaxHeap(l)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, h.(*Heap))
+
+	if h.String() != "[(5 5) (4 4) (3 3) (1.5 1.5) (1 1)]" {
+		t.Fatalf("Got %s, which was unexpected", h.String())
+	}
+
+	sorted, _ := list.MakeList(h)
+	if sorted.String() != "[5 4 3 1.5 1]" || h.Length() != 5 {
+		t.Fatalf("Got %s, which was unexpected", sorted.String())
+	}
+
+	bad, _ := list.MakeListFromValues(1, []int{1}, map[int]int{})
+	if _, err := MakeMinHeap(bad); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	h, _ := MakeMinHeap()
+	items := make([]*Item, 0)
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		item, _ := h.Push(name, 10*(i+1))
+		items = append(items, item)
+	}
+
+	if err := h.Update(items[4], 5); err != nil {
+		t.Error(err)
+	} else if value, _, _ := h.Peek(); value != "e" {
+		t.Fatalf("Got %v, was expecting e", value)
+	}
+
+	if err := h.Update(items[4], 100); err != nil {
+		t.Error(err)
+	} else if err := h.Update(items[0], 35); err != nil {
+		t.Error(err)
+	} else if h.String() != "[(b 20) (c 30) (a 35) (d 40) (e 100)]" {
+		t.Fatalf("Got %s, which was unexpected", h.String())
+	}
+	check(t, h.(*Heap))
+
+	if err := h.DecreaseKey(items[3], 1); err != nil {
+		t.Error(err)
+	} else if value, priority, _ := h.Peek(); value != "d" || priority != 1 {
+		t.Fatalf("Got %v and %v, was expecting d and 1", value, priority)
+	} else if items[3].Priority() != 1 || items[3].Value() != "d" {
+		t.Fatalf("Got %v, was expecting 1", items[3].Priority())
+	}
+
+	if err := h.DecreaseKey(items[1], 50); err == nil {
+		t.Fatal("Expected error increasing priority with DecreaseKey")
+	} else if err := h.Update(items[1], "z"); err != nil {
+		t.Error(err)
+	} else if err := h.Update(items[1], []int{1}); err == nil {
+		t.Fatal("Expected comparison error")
+	} else if items[1].Priority() != "z" {
+		t.Fatal("Expected priority to be unchanged")
+	}
+	check(t, h.(*Heap))
+
+	if err := h.Remove(items[2]); err != nil {
+		t.Error(err)
+	} else if err := h.Remove(items[2]); err == nil {
+		t.Fatal("Expected error removing item twice")
+	} else if err := h.Update(items[2], 1); err == nil {
+		t.Fatal("Expected error updating removed item")
+	} else if h.String() != "[(d 1) (a 35) (e 100) (b z)]" {
+		t.Fatalf("Got %s, which was unexpected", h.String())
+	}
+	check(t, h.(*Heap))
+
+	other, _ := MakeMinHeap()
+	if err := other.Update(items[0], 1); err == nil {
+		t.Fatal("Expected error updating item of another heap")
+	}
+
+	h.Clear()
+	if err := h.Update(items[0], 1); err == nil || h.Length() != 0 {
+		t.Fatal("Expected error updating cleared item")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	h1, _ := MakeMinHeap()
+	h2, _ := MakeMinHeap()
+	a, _ := h1.Push("a", 3)
+	h1.Push("b", 1)
+	c, _ := h2.Push("c", 2)
+	h2.Push("d", 0)
+
+	if err := h1.Merge(h2); err != nil {
+		t.Error(err)
+	} else if h1.String() != "[(d 0) (b 1) (c 2) (a 3)]" || h2.Length() != 2 {
+		t.Fatalf("Got %s and %s, which was unexpected", h1.String(), h2.String())
+	}
+	check(t, h1.(*Heap))
+
+	if err := h1.Update(c, 5); err == nil {
+		t.Fatal("Expected error updating item of the other heap")
+	} else if err := h1.DecreaseKey(a, -1); err != nil {
+		t.Error(err)
+	} else if value, _, _ := h1.Peek(); value != "a" {
+		t.Fatalf("Got %v, was expecting a", value)
+	}
+
+	bad, _ := MakeMinHeap()
+	bad.Push("x", "x")
+	maxHeap, _ := MakeHeapWithCompare(func(a interface{}, b interface{}) (int, error) {
+		if _, ok := a.(int); !ok {
+			return 0, &order.IncomparableError{A: a, B: b}
+		}
+		if _, ok := b.(int); !ok {
+			return 0, &order.IncomparableError{A: a, B: b}
+		}
+		return b.(int) - a.(int), nil
+	})
+	maxHeap.Push("y", 1)
+	maxHeap.Push("z", 2)
+	if err := maxHeap.Merge(bad); err == nil {
+		t.Fatal("Expected comparison error")
+	} else if maxHeap.String() != "[(z 2) (y 1)]" {
+		t.Fatalf("Got %s, which was unexpected", maxHeap.String())
+	}
+	check(t, maxHeap.(*Heap))
+
+	c2, err := h1.Copy()
+	if err != nil {
+		t.Error(err)
+	} else if c2.Pop(); c2.Length() != 3 || h1.Length() != 4 {
+		t.Fatalf("Got %v and %v, which was unexpected", c2, h1)
+	}
+}
@@ -0,0 +1,43 @@
+package heap
+
+import "github.com/dynago/dg/iterable"
+
+// HeapInterface is the interface which defines whether a struct is a heap or not.
+type HeapInterface interface {
+	/* Return the number of items in heap. */
+	Length() int
+	/* Return the next value in heap, in priority order. */
+	Iterate() <-chan interface{}
+	/* Call the function on each value in heap, in priority order, until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the values in heap, in priority order. */
+	Iterator() iterable.Iterator
+	/* Call the function on each item in heap, in no particular order, until it returns false. */
+	ForEachItem(func(*Item) bool)
+
+	/* Add the value with the priority, and return its item. */
+	Push(interface{}, interface{}) (*Item, error)
+	/* Pop and return the value and priority at the top of the heap. */
+	Pop() (interface{}, interface{}, error)
+	/* Return the value and priority at the top of the heap without removing them. */
+	Peek() (interface{}, interface{}, error)
+	/* Change the priority of the item. */
+	Update(*Item, interface{}) error
+	/* Change the priority of the item to one which is no further from the top of the heap. */
+	DecreaseKey(*Item, interface{}) error
+	/* Remove the item from the heap. */
+	Remove(*Item) error
+	/* Add copies of the items in the other heap. */
+	Merge(HeapInterface) error
+	/* Clear all items from the heap. */
+	Clear() error
+
+	/* Creates a copy of the current HeapInterface. */
+	Copy() (HeapInterface, error)
+
+	/* Returns a string representation of the heap. */
+	String() string
+
+	/* Initializes the heap. */
+	Init()
+}
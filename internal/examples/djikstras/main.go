@@ -4,17 +4,29 @@ import (
 	"fmt"
 
 	"github.com/dynago/dg/dict"
-	"github.com/dynago/dg/set"
+	"github.com/dynago/dg/heap"
 	"github.com/dynago/dg/list"
+	"github.com/dynago/dg/set"
 )
 
-/* This is an example using dictionaries to implement Djikstra's Algorithm */
+/* This is an example using dictionaries and a heap to implement Djikstra's Algorithm */
 
 type Edge struct {
 	node   string
 	weight uint
 }
 
+/* HashKey returns the bytes which identify the edge. */
+func (e Edge) HashKey() ([]byte, error) {
+	return []byte(fmt.Sprintf("%s:%d", e.node, e.weight)), nil
+}
+
+/* EqualTo returns true if the other value is the same edge. */
+func (e Edge) EqualTo(other interface{}) bool {
+	o, ok := other.(Edge)
+	return ok && o == e
+}
+
 const UNVISITED = ^uint(0) - 1
 
 func getUInt(value interface{}) uint {
@@ -26,21 +38,6 @@ func getUInt(value interface{}) uint {
 	default:
 		panic("interface{} should convert to either int or uint")
 	}
-	return 0
-}
-
-func minNode(unvisited dict.DictInterface) (string, uint) {
-	minN := ""
-	minD := UNVISITED + 1
-	for node := range unvisited.Iterate() {
-		d, _ := unvisited.Get(node)
-		distance := getUInt(d)
-		if distance < minD {
-			minN = node.(string)
-			minD = distance
-		}
-	}
-	return minN, minD
 }
 
 func getPath(start string, end string, parents dict.DictInterface) list.ListInterface {
@@ -74,7 +71,6 @@ a -> c: distance = 1,   path = [a c]
 a -> d: distance = inf, path = []
 */
 
-
 func main() {
 	// Edges
 	a, _ := set.MakeSetFromValues(Edge{"b", 3}, Edge{"c", 1})
@@ -89,15 +85,22 @@ func main() {
 
 	start := "a"
 
-	// unvisited nodes -> distances
-	unvisited, _ := dict.MakeDictFromKeyValues(nodes, []interface{}{UNVISITED, UNVISITED, UNVISITED, UNVISITED})
-	unvisited.Set(start, 0) // set vertex to distance = 0
+	// unvisited nodes ordered by distance
+	unvisited, _ := heap.MakeMinHeap()
+	items, _ := dict.MakeDict() // node -> item in unvisited
+	for _, node := range nodes {
+		item, _ := unvisited.Push(node, UNVISITED)
+		items.Set(node, item)
+	}
+	startItem, _ := items.Get(start)
+	unvisited.DecreaseKey(startItem.(*heap.Item), uint(0)) // set vertex to distance = 0
 
 	visited, _ := dict.MakeDict()
 	parents, _ := dict.MakeDict()
 
 	for unvisited.Length() > 0 {
-		minN, minD := minNode(unvisited)
+		node, distance, _ := unvisited.Pop()
+		minN, minD := node.(string), getUInt(distance)
 
 		neighbours, _ := graph.Get(minN)
 		neighbourSet := neighbours.(*set.Set)
@@ -105,20 +108,20 @@ func main() {
 			neighbour := n.(Edge)
 			if contains, _ := visited.Contains(neighbour.node); !contains {
 				newD := minD + neighbour.weight
-				if neighD, _ := unvisited.Get(neighbour.node); newD < getUInt(neighD) {
-					unvisited.Set(neighbour.node, newD)
-                    parents.Set(neighbour.node, minN)
+				i, _ := items.Get(neighbour.node)
+				if item := i.(*heap.Item); newD < getUInt(item.Priority()) {
+					unvisited.DecreaseKey(item, newD)
+					parents.Set(neighbour.node, minN)
 				}
 			}
 		}
 
 		visited.Set(minN, minD)
-        unvisited.Remove(minN)
 	}
 
 	for _, end := range nodes {
 		fmt.Printf("%s -> %s: ", start, end)
-		distance, _ := visited.Get(end.(string)) 
+		distance, _ := visited.Get(end.(string))
 		if distance == UNVISITED {
 			fmt.Println("distance = inf,\tpath = []")
 		} else {
@@ -5,20 +5,22 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+
+	"github.com/dynago/dg/errs"
 )
 
-/* GetSHA - Returns a string hash based on the given value */
+/* GetSHA - Returns a string hash based on the given value, or an *errs.HashError if it cannot be marshalled */
 func GetSHA(value interface{}) (string, error) {
 	if value == nil {
-		return "", fmt.Errorf("Cannot generate SHA from nil")
+		return "", &errs.HashError{Value: value, Err: fmt.Errorf("Cannot generate SHA from nil")}
 	}
 	b1, err1 := json.Marshal(value)
 	if err1 != nil {
-		return "", err1
+		return "", &errs.HashError{Value: value, Err: err1}
 	}
 	b2, err2 := json.Marshal(fmt.Sprintf("%t", value))
 	if err2 != nil {
-		return "", err2
+		return "", &errs.HashError{Value: value, Err: err2}
 	}
 	hasher := sha1.New()
 	hasher.Write(b1)
@@ -27,8 +29,11 @@ func GetSHA(value interface{}) (string, error) {
 	return sha, nil
 }
 
-/* ValidIndex - Returns a valid index given the length of the list. */
+/* ValidIndex - Returns a valid index given the length of the list. Negative indexes count from the end, as in Python, and the result is clamped to [0, length]. */
 func ValidIndex(i int, length int) int {
+	if i < 0 {
+		i += length
+	}
 	if i < 0 {
 		return 0
 	}
@@ -37,3 +42,42 @@ func ValidIndex(i int, length int) int {
 	}
 	return i
 }
+
+/* ElementIndex - Returns the index of an element given the length of the list, counting negative indexes from the end, and false if there is no such element. */
+func ElementIndex(i int, length int) (int, bool) {
+	if i < 0 {
+		i += length
+	}
+	return i, i >= 0 && i < length
+}
+
+/* SliceIndices - Returns the first index and number of elements of the slice start:stop:step of a list of the given length, with the same semantics as Python. The elements are at first, first+step, first+2*step and so on. */
+func SliceIndices(start int, stop int, step int, length int) (int, int, error) {
+	if step == 0 {
+		return 0, 0, fmt.Errorf("Slice step cannot be zero")
+	}
+	lower, upper := 0, length
+	if step < 0 {
+		lower, upper = -1, length-1
+	}
+	clamp := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		if i < lower {
+			return lower
+		}
+		if i > upper {
+			return upper
+		}
+		return i
+	}
+	start, stop = clamp(start), clamp(stop)
+	count := 0
+	if step > 0 && start < stop {
+		count = (stop-start-1)/step + 1
+	} else if step < 0 && stop < start {
+		count = (start-stop-1)/(-step) + 1
+	}
+	return start, count, nil
+}
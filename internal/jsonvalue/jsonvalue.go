@@ -0,0 +1,282 @@
+// Package jsonvalue encodes and decodes the JSON representation of dynamic structures.
+package jsonvalue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/dynago/dg/errs"
+)
+
+// Constructors for the structures built when decoding. Each is set by the package which defines the structure, so that nested structures can be decoded without import cycles.
+var (
+	MakeDict  func(keys []interface{}, values []interface{}) (interface{}, error)
+	MakeList  func(values []interface{}) (interface{}, error)
+	MakeTuple func(values []interface{}) (interface{}, error)
+)
+
+/* IsNull returns true if the data is the JSON null literal. */
+func IsNull(data []byte) bool {
+	return string(bytes.TrimSpace(data)) == "null"
+}
+
+/* EncodeArray encodes the values as a JSON array. If sorted is true the encoded values are sorted, so that unordered structures always encode the same way. */
+func EncodeArray(values []interface{}, sorted bool) ([]byte, error) {
+	encoded := make([][]byte, len(values))
+	for i, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = b
+	}
+	if sorted {
+		sort.Slice(encoded, func(i, j int) bool {
+			return bytes.Compare(encoded[i], encoded[j]) < 0
+		})
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	buf.Write(bytes.Join(encoded, []byte{','}))
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+/* EncodeObject encodes the keys and values as a JSON object. If sorted is true the keys are sorted, so that unordered structures always encode the same way; otherwise they are kept in order. */
+func EncodeObject(keys []string, values []interface{}, sorted bool) ([]byte, error) {
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	if sorted {
+		sort.Slice(order, func(i, j int) bool {
+			return keys[order[i]] < keys[order[j]]
+		})
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for n, i := range order {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(keys[i])
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+/* Decode decodes a JSON document. Objects become dicts and arrays become lists, or tuples if hashable is true. Numbers become an int if they are integers which fit, and a float64 otherwise. */
+func Decode(data []byte, hashable bool) (interface{}, error) {
+	dec, err := newDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+	value, err := decodeValue(dec, hashable)
+	if err != nil {
+		return nil, err
+	}
+	return value, finish(dec)
+}
+
+/* DecodeArray decodes the elements of a JSON array. Nested arrays become tuples if hashable is true. */
+func DecodeArray(data []byte, hashable bool) ([]interface{}, error) {
+	dec, err := newDecoder(data)
+	if err != nil {
+		return nil, err
+	}
+	if err = expect(dec, '['); err != nil {
+		return nil, err
+	}
+	values, err := decodeElements(dec, hashable)
+	if err != nil {
+		return nil, err
+	}
+	return values, finish(dec)
+}
+
+/* DecodeItems decodes the keys and values of a JSON object, or of a JSON array of [key, value] pairs. Arrays in keys become tuples. */
+func DecodeItems(data []byte) ([]interface{}, []interface{}, error) {
+	dec, err := newDecoder(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	var keys, values []interface{}
+	switch tok {
+	case json.Delim('{'):
+		keys, values, err = decodeMembers(dec)
+	case json.Delim('['):
+		keys, values, err = decodePairs(dec)
+	default:
+		err = fmt.Errorf("Cannot decode %v as a dict", tok)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return keys, values, finish(dec)
+}
+
+/* newDecoder returns a decoder for valid JSON data which keeps numbers as json.Number. */
+func newDecoder(data []byte) (*json.Decoder, error) {
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("Invalid JSON: %s", data)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec, nil
+}
+
+/* finish returns an error if there is data after the decoded value. */
+func finish(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("Unexpected data after JSON value")
+	}
+	return nil
+}
+
+/* expect reads the next token and returns an error if it is not the given delimiter. */
+func expect(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("Expected %v, got %v", delim, tok)
+	}
+	return nil
+}
+
+/* decodeValue decodes the next value from the decoder. */
+func decodeValue(dec *json.Decoder, hashable bool) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			keys, values, err := decodeMembers(dec)
+			if err != nil {
+				return nil, err
+			}
+			if MakeDict == nil {
+				return nil, missing("dict")
+			}
+			return MakeDict(keys, values)
+		}
+		values, err := decodeElements(dec, hashable)
+		if err != nil {
+			return nil, err
+		}
+		if hashable {
+			if MakeTuple == nil {
+				return nil, missing("tuple")
+			}
+			return MakeTuple(values)
+		}
+		if MakeList == nil {
+			return nil, missing("list")
+		}
+		return MakeList(values)
+	case json.Number:
+		return number(t)
+	default:
+		return t, nil
+	}
+}
+
+/* decodeElements decodes array elements up to and including the closing bracket. */
+func decodeElements(dec *json.Decoder, hashable bool) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	for dec.More() {
+		value, err := decodeValue(dec, hashable)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	_, err := dec.Token()
+	return values, err
+}
+
+/* decodeMembers decodes object members up to and including the closing brace. */
+func decodeMembers(dec *json.Decoder) ([]interface{}, []interface{}, error) {
+	keys := make([]interface{}, 0)
+	values := make([]interface{}, 0)
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		value, err := decodeValue(dec, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	_, err := dec.Token()
+	return keys, values, err
+}
+
+/* decodePairs decodes [key, value] pairs up to and including the closing bracket of the enclosing array. */
+func decodePairs(dec *json.Decoder) ([]interface{}, []interface{}, error) {
+	keys := make([]interface{}, 0)
+	values := make([]interface{}, 0)
+	for dec.More() {
+		if err := expect(dec, '['); err != nil {
+			return nil, nil, err
+		}
+		pair := make([]interface{}, 0, 2)
+		for i := 0; dec.More(); i++ {
+			if i == 2 {
+				return nil, nil, &errs.LengthMismatchError{Length: 3, Expected: 2, Of: "item"}
+			}
+			value, err := decodeValue(dec, i == 0)
+			if err != nil {
+				return nil, nil, err
+			}
+			pair = append(pair, value)
+		}
+		if len(pair) != 2 {
+			return nil, nil, &errs.LengthMismatchError{Length: len(pair), Expected: 2, Of: "item"}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, pair[0])
+		values = append(values, pair[1])
+	}
+	_, err := dec.Token()
+	return keys, values, err
+}
+
+/* missing returns the error for a structure whose package has not set its constructor. */
+func missing(name string) error {
+	return fmt.Errorf("Cannot decode JSON into a %s without importing the %s package", name, name)
+}
+
+/* number returns the number as an int if it is an integer which fits, and as a float64 otherwise. */
+func number(n json.Number) (interface{}, error) {
+	if i, err := strconv.ParseInt(string(n), 10, 0); err == nil {
+		return int(i), nil
+	}
+	return n.Float64()
+}
@@ -0,0 +1,371 @@
+// Package tree implements an AVL tree of key/value pairs which also supports finding keys by rank.
+package tree
+
+// node is a key/value pair in the tree.
+type node struct {
+	key    interface{}
+	value  interface{}
+	left   *node
+	right  *node
+	height int // height of the subtree rooted at the node
+	size   int // number of nodes in the subtree rooted at the node
+}
+
+// Tree is a balanced binary search tree ordered by a comparison function. Every operation except walking the tree takes O(log n) time.
+type Tree struct {
+	root    *node
+	compare func(a interface{}, b interface{}) (int, error)
+}
+
+/* New returns an empty tree ordered by the comparison function, which returns -1, 0 or 1 as a is less than, equal to or greater than b. */
+func New(compare func(a interface{}, b interface{}) (int, error)) *Tree {
+	return &Tree{compare: compare}
+}
+
+/* Build returns a tree ordered by the comparison function holding the keys and values, which must be sorted by key with no two keys equal. It takes O(n) time. */
+func Build(compare func(a interface{}, b interface{}) (int, error), keys []interface{}, values []interface{}) *Tree {
+	return &Tree{root: build(keys, values), compare: compare}
+}
+
+func build(keys []interface{}, values []interface{}) *node {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	n := &node{key: keys[mid], value: values[mid]}
+	n.left = build(keys[:mid], values[:mid])
+	n.right = build(keys[mid+1:], values[mid+1:])
+	update(n)
+	return n
+}
+
+/* Len returns the number of keys in the tree. */
+func (t *Tree) Len() int {
+	return size(t.root)
+}
+
+/* Clear removes every key from the tree. */
+func (t *Tree) Clear() {
+	t.root = nil
+}
+
+/* Get returns the value of the key, and false if the key is not in the tree. */
+func (t *Tree) Get(key interface{}) (interface{}, bool, error) {
+	n := t.root
+	for n != nil {
+		c, err := t.compare(key, n.key)
+		if err != nil {
+			return nil, false, err
+		}
+		switch {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+/* Put sets the value of the key, and returns true if the key was added rather than replaced. A replaced key keeps the key it was first added with. */
+func (t *Tree) Put(key interface{}, value interface{}) (bool, error) {
+	root, added, err := t.put(t.root, key, value)
+	if err != nil {
+		return false, err
+	}
+	t.root = root
+	return added, nil
+}
+
+func (t *Tree) put(n *node, key interface{}, value interface{}) (*node, bool, error) {
+	if n == nil {
+		return &node{key: key, value: value, height: 1, size: 1}, true, nil
+	}
+	c, err := t.compare(key, n.key)
+	if err != nil {
+		return nil, false, err
+	}
+	var child *node
+	added := false
+	switch {
+	case c < 0:
+		if child, added, err = t.put(n.left, key, value); err != nil {
+			return nil, false, err
+		}
+		n.left = child
+	case c > 0:
+		if child, added, err = t.put(n.right, key, value); err != nil {
+			return nil, false, err
+		}
+		n.right = child
+	default:
+		n.value = value
+		return n, false, nil
+	}
+	return balance(n), added, nil
+}
+
+/* Delete removes the key, and returns its key and value and true if it was in the tree. */
+func (t *Tree) Delete(key interface{}) (interface{}, interface{}, bool, error) {
+	root, removed, err := t.delete(t.root, key)
+	if err != nil || removed == nil {
+		return nil, nil, false, err
+	}
+	t.root = root
+	return removed.key, removed.value, true, nil
+}
+
+func (t *Tree) delete(n *node, key interface{}) (*node, *node, error) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	c, err := t.compare(key, n.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	var child, removed *node
+	switch {
+	case c < 0:
+		if child, removed, err = t.delete(n.left, key); err != nil || removed == nil {
+			return n, removed, err
+		}
+		n.left = child
+	case c > 0:
+		if child, removed, err = t.delete(n.right, key); err != nil || removed == nil {
+			return n, removed, err
+		}
+		n.right = child
+	default:
+		removed = &node{key: n.key, value: n.value}
+		if n.left == nil {
+			return n.right, removed, nil
+		}
+		if n.right == nil {
+			return n.left, removed, nil
+		}
+		successor := n.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		n.key, n.value = successor.key, successor.value
+		n.right = deleteMin(n.right)
+	}
+	return balance(n), removed, nil
+}
+
+/* deleteMin removes the smallest key from the subtree and returns its new root. */
+func deleteMin(n *node) *node {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = deleteMin(n.left)
+	return balance(n)
+}
+
+/* Min returns the smallest key and its value, and false if the tree is empty. */
+func (t *Tree) Min() (interface{}, interface{}, bool) {
+	n := t.root
+	if n == nil {
+		return nil, nil, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, n.value, true
+}
+
+/* Max returns the largest key and its value, and false if the tree is empty. */
+func (t *Tree) Max() (interface{}, interface{}, bool) {
+	n := t.root
+	if n == nil {
+		return nil, nil, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, n.value, true
+}
+
+/* Floor returns the largest key which is less than or equal to the key, and false if there is none. */
+func (t *Tree) Floor(key interface{}) (interface{}, bool, error) {
+	var found *node
+	n := t.root
+	for n != nil {
+		c, err := t.compare(key, n.key)
+		if err != nil {
+			return nil, false, err
+		}
+		if c == 0 {
+			return n.key, true, nil
+		} else if c < 0 {
+			n = n.left
+		} else {
+			found, n = n, n.right
+		}
+	}
+	if found == nil {
+		return nil, false, nil
+	}
+	return found.key, true, nil
+}
+
+/* Ceiling returns the smallest key which is greater than or equal to the key, and false if there is none. */
+func (t *Tree) Ceiling(key interface{}) (interface{}, bool, error) {
+	var found *node
+	n := t.root
+	for n != nil {
+		c, err := t.compare(key, n.key)
+		if err != nil {
+			return nil, false, err
+		}
+		if c == 0 {
+			return n.key, true, nil
+		} else if c > 0 {
+			n = n.right
+		} else {
+			found, n = n, n.left
+		}
+	}
+	if found == nil {
+		return nil, false, nil
+	}
+	return found.key, true, nil
+}
+
+/* Rank returns the number of keys in the tree which are less than the key. */
+func (t *Tree) Rank(key interface{}) (int, error) {
+	rank := 0
+	n := t.root
+	for n != nil {
+		c, err := t.compare(key, n.key)
+		if err != nil {
+			return 0, err
+		}
+		if c <= 0 {
+			n = n.left
+		} else {
+			rank += size(n.left) + 1
+			n = n.right
+		}
+	}
+	return rank, nil
+}
+
+/* Select returns the key with the given rank and its value, and false if the rank is not in [0, Len()). */
+func (t *Tree) Select(rank int) (interface{}, interface{}, bool) {
+	if rank < 0 || rank >= t.Len() {
+		return nil, nil, false
+	}
+	n := t.root
+	for {
+		left := size(n.left)
+		if rank < left {
+			n = n.left
+		} else if rank > left {
+			rank -= left + 1
+			n = n.right
+		} else {
+			return n.key, n.value, true
+		}
+	}
+}
+
+/* Ascend calls the function on each key and value in ascending order of key until it returns false. The tree must not be changed by the function. */
+func (t *Tree) Ascend(fn func(key interface{}, value interface{}) bool) {
+	ascend(t.root, fn)
+}
+
+func ascend(n *node, fn func(key interface{}, value interface{}) bool) bool {
+	if n == nil {
+		return true
+	}
+	return ascend(n.left, fn) && fn(n.key, n.value) && ascend(n.right, fn)
+}
+
+/* AscendFrom calls the function on each key and value, in ascending order, starting from the smallest key greater than or equal to the given key, until the function returns false. The tree must not be changed by the function. */
+func (t *Tree) AscendFrom(key interface{}, fn func(key interface{}, value interface{}) bool) error {
+	_, err := t.ascendFrom(t.root, key, fn)
+	return err
+}
+
+func (t *Tree) ascendFrom(n *node, key interface{}, fn func(key interface{}, value interface{}) bool) (bool, error) {
+	if n == nil {
+		return true, nil
+	}
+	c, err := t.compare(key, n.key)
+	if err != nil {
+		return false, err
+	}
+	if c > 0 {
+		return t.ascendFrom(n.right, key, fn)
+	}
+	if more, err := t.ascendFrom(n.left, key, fn); err != nil || !more {
+		return more, err
+	}
+	return fn(n.key, n.value) && ascend(n.right, fn), nil
+}
+
+func size(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func height(n *node) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+/* update recomputes the height and size of the node from its children. */
+func update(n *node) {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.size = 1 + size(n.left) + size(n.right)
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func rotateLeft(n *node) *node {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	update(n)
+	update(r)
+	return r
+}
+
+func rotateRight(n *node) *node {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	update(n)
+	update(l)
+	return l
+}
+
+/* balance restores the AVL property at the node after one of its subtrees changed height by at most one, and returns the new root of the subtree. */
+func balance(n *node) *node {
+	update(n)
+	switch factor := height(n.left) - height(n.right); {
+	case factor > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case factor < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
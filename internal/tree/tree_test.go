@@ -0,0 +1,168 @@
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func compareInts(a interface{}, b interface{}) (int, error) {
+	x, ok1 := a.(int)
+	y, ok2 := b.(int)
+	if !ok1 || !ok2 {
+		return 0, fmt.Errorf("Cannot compare %v and %v", a, b)
+	}
+	if x < y {
+		return -1, nil
+	} else if x > y {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+/* check returns the height and size of the subtree, failing if it is not a balanced search tree with correct heights and sizes. */
+func check(t *testing.T, n *node, lo int, hi int) (int, int) {
+	if n == nil {
+		return 0, 0
+	}
+	key := n.key.(int)
+	if key < lo || key > hi {
+		t.Fatalf("Key %d is out of order", key)
+	}
+	lh, ls := check(t, n.left, lo, key-1)
+	rh, rs := check(t, n.right, key+1, hi)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("Node %d is unbalanced: %d and %d", key, lh, rh)
+	}
+	if n.height != 1+max(lh, rh) || n.size != 1+ls+rs {
+		t.Fatalf("Node %d has height %d and size %d, was expecting %d and %d", key, n.height, n.size, 1+max(lh, rh), 1+ls+rs)
+	}
+	return n.height, n.size
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := New(compareInts)
+	reference := make(map[int]int)
+	for i := 0; i < 5000; i++ {
+		key := r.Intn(500)
+		if r.Intn(3) == 0 {
+			_, value, found, err := tr.Delete(key)
+			expected, ok := reference[key]
+			if err != nil {
+				t.Fatal(err)
+			} else if found != ok || (ok && value != expected) {
+				t.Fatalf("Delete(%d) returned %v and %v, was expecting %v and %v", key, value, found, expected, ok)
+			}
+			delete(reference, key)
+		} else {
+			added, err := tr.Put(key, i)
+			_, ok := reference[key]
+			if err != nil {
+				t.Fatal(err)
+			} else if added == ok {
+				t.Fatalf("Put(%d) returned %v, was expecting %v", key, added, !ok)
+			}
+			reference[key] = i
+		}
+		check(t, tr.root, -1, 500)
+	}
+
+	keys := make([]int, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	if tr.Len() != len(keys) {
+		t.Fatalf("Got length %d, was expecting %d", tr.Len(), len(keys))
+	}
+	for i, key := range keys {
+		if value, ok, err := tr.Get(key); err != nil || !ok || value != reference[key] {
+			t.Fatalf("Get(%d) returned %v, was expecting %d", key, value, reference[key])
+		}
+		if rank, err := tr.Rank(key); err != nil || rank != i {
+			t.Fatalf("Rank(%d) returned %d, was expecting %d", key, rank, i)
+		}
+		if k, _, ok := tr.Select(i); !ok || k != key {
+			t.Fatalf("Select(%d) returned %v, was expecting %d", i, k, key)
+		}
+	}
+
+	i := 0
+	tr.Ascend(func(key interface{}, value interface{}) bool {
+		if key != keys[i] {
+			t.Fatalf("Got %v at %d, was expecting %d", key, i, keys[i])
+		}
+		i++
+		return true
+	})
+	if i != len(keys) {
+		t.Fatalf("Ascend visited %d keys, was expecting %d", i, len(keys))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tr := New(compareInts)
+	for _, key := range []int{10, 20, 30, 40} {
+		tr.Put(key, key)
+	}
+
+	if key, ok, err := tr.Floor(25); err != nil || !ok || key != 20 {
+		t.Fatalf("Got %v, was expecting 20", key)
+	}
+	if key, ok, err := tr.Floor(30); err != nil || !ok || key != 30 {
+		t.Fatalf("Got %v, was expecting 30", key)
+	}
+	if _, ok, err := tr.Floor(5); err != nil || ok {
+		t.Fatal("Expected no floor below the smallest key")
+	}
+	if key, ok, err := tr.Ceiling(25); err != nil || !ok || key != 30 {
+		t.Fatalf("Got %v, was expecting 30", key)
+	}
+	if _, ok, err := tr.Ceiling(45); err != nil || ok {
+		t.Fatal("Expected no ceiling above the largest key")
+	}
+	if rank, err := tr.Rank(35); err != nil || rank != 3 {
+		t.Fatalf("Got %d, was expecting 3", rank)
+	}
+	if _, _, ok := tr.Select(4); ok {
+		t.Fatal("Expected no key at rank 4")
+	}
+
+	keys := []interface{}{}
+	if err := tr.AscendFrom(15, func(key interface{}, value interface{}) bool {
+		keys = append(keys, key)
+		return key != 30
+	}); err != nil {
+		t.Error(err)
+	} else if fmt.Sprint(keys) != "[20 30]" {
+		t.Fatalf("Got %v, was expecting [20 30]", keys)
+	}
+
+	if _, err := tr.Put("a", 1); err == nil {
+		t.Fatal("Expected comparison error")
+	} else if tr.Len() != 4 {
+		t.Fatalf("Got length %d, was expecting the tree to be unchanged", tr.Len())
+	}
+	if _, _, _, err := tr.Delete("a"); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+}
+
+func TestBuild(t *testing.T) {
+	for n := 0; n < 40; n++ {
+		keys := make([]interface{}, n)
+		for i := range keys {
+			keys[i] = i * 2
+		}
+		tr := Build(compareInts, keys, make([]interface{}, n))
+		if _, size := check(t, tr.root, -1, 2*n); size != n {
+			t.Fatalf("Got size %d, was expecting %d", size, n)
+		}
+		if _, err := tr.Put(2*n+1, nil); err != nil {
+			t.Error(err)
+		}
+		check(t, tr.root, -1, 2*n+1)
+	}
+}
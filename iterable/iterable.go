@@ -0,0 +1,132 @@
+// Package iterable defines the interfaces for iterating over dg structures. Every structure is an Iterable, and its Iterator method returns a pull-style Iterator over a snapshot of its values.
+package iterable
+
+// Iterable is the interface implemented by every structure, and accepted by the constructors which fill a structure from another.
+type Iterable interface {
+	/* Return the next key in set. */
+	Iterate() <-chan interface{}
+}
+
+// Iterator is a pull-style iterator which does not need a goroutine.
+type Iterator interface {
+	/* Advance to the next value. Returns false when there are no more values. */
+	Next() bool
+	/* Return the current value. */
+	Value() interface{}
+	/* Release the iterator. It is safe to call more than once. */
+	Close()
+}
+
+// ForEacher is implemented by iterables which can visit their values without a goroutine.
+type ForEacher interface {
+	/* Call the function on each value until it returns false. */
+	ForEach(func(interface{}) bool)
+}
+
+// IteratorSource is implemented by iterables which can return an Iterator.
+type IteratorSource interface {
+	/* Return an Iterator over the values. */
+	Iterator() Iterator
+}
+
+/* ForEach calls fn on each value of the Iterable until fn returns false. Iterables without a ForEach method are drained, so their goroutine always finishes. */
+func ForEach(it Iterable, fn func(interface{}) bool) {
+	if f, ok := it.(ForEacher); ok {
+		f.ForEach(fn)
+		return
+	}
+	c := it.Iterate()
+	for value := range c {
+		if !fn(value) {
+			for range c {
+			}
+			return
+		}
+	}
+}
+
+/* Iter returns an Iterator over the Iterable. */
+func Iter(it Iterable) Iterator {
+	if s, ok := it.(IteratorSource); ok {
+		return s.Iterator()
+	}
+	return &chanIterator{c: it.Iterate()}
+}
+
+/* Channel returns a channel which receives every value of the ForEacher. It is the adapter behind Iterate, and the channel must be read until it is closed. */
+func Channel(f ForEacher) <-chan interface{} {
+	c := make(chan interface{})
+	go func() {
+		f.ForEach(func(value interface{}) bool {
+			c <- value
+			return true
+		})
+		close(c)
+	}()
+	return c
+}
+
+/* FromSlice returns an Iterator over the values. */
+func FromSlice(values []interface{}) Iterator {
+	return &sliceIterator{values: values, i: -1}
+}
+
+// sliceIterator is an Iterator over a slice.
+type sliceIterator struct {
+	values []interface{}
+	i      int
+}
+
+func (s *sliceIterator) Next() bool {
+	if s.i+1 >= len(s.values) {
+		s.i = len(s.values)
+		return false
+	}
+	s.i += 1
+	return true
+}
+
+func (s *sliceIterator) Value() interface{} {
+	if s.i < 0 || s.i >= len(s.values) {
+		return nil
+	}
+	return s.values[s.i]
+}
+
+func (s *sliceIterator) Close() {
+	s.values = nil
+	s.i = 0
+}
+
+// chanIterator is an Iterator over the channel returned by Iterate.
+type chanIterator struct {
+	c     <-chan interface{}
+	value interface{}
+}
+
+func (c *chanIterator) Next() bool {
+	if c.c == nil {
+		return false
+	}
+	value, ok := <-c.c
+	if !ok {
+		c.c = nil
+		c.value = nil
+		return false
+	}
+	c.value = value
+	return true
+}
+
+func (c *chanIterator) Value() interface{} {
+	return c.value
+}
+
+func (c *chanIterator) Close() {
+	if c.c != nil {
+		for range c.c {
+		}
+		c.c = nil
+	}
+	c.value = nil
+}
@@ -0,0 +1,79 @@
+package list
+
+import (
+	"encoding/gob"
+
+	"github.com/dynago/dg/codec"
+)
+
+func init() {
+	build := func(values []interface{}) (interface{}, error) {
+		return MakeListFromValues(values...)
+	}
+	codec.RegisterContainer("list", (*List)(nil), binaryElements, build)
+	codec.RegisterContainer("list", (*SyncList)(nil), binaryElements, build)
+	gob.Register(new(List))
+	gob.Register(new(SyncList))
+}
+
+/* MarshalBinary encodes the list, preserving the type of every element. */
+func (l *List) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(l)
+}
+
+/* UnmarshalBinary decodes a list encoded by MarshalBinary into the list. */
+func (l *List) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "list")
+	if err != nil {
+		return err
+	}
+	l.values = values
+	return nil
+}
+
+/* GobEncode encodes the list for encoding/gob. */
+func (l *List) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+/* GobDecode decodes the list for encoding/gob. */
+func (l *List) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes a snapshot of the list, preserving the type of every element. */
+func (l *SyncList) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(l)
+}
+
+/* UnmarshalBinary decodes a list encoded by MarshalBinary into the list. */
+func (l *SyncList) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "list")
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.list = &List{values: values}
+	return nil
+}
+
+/* GobEncode encodes the list for encoding/gob. */
+func (l *SyncList) GobEncode() ([]byte, error) {
+	return l.MarshalBinary()
+}
+
+/* GobDecode decodes the list for encoding/gob. */
+func (l *SyncList) GobDecode(data []byte) error {
+	return l.UnmarshalBinary(data)
+}
+
+/* binaryElements returns the values of the list to encode. */
+func binaryElements(l interface{}) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	l.(ListInterface).ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values, nil
+}
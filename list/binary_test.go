@@ -0,0 +1,52 @@
+package list
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	inner, _ := MakeListFromValues(int8(1), "a")
+	l, _ := MakeListFromValues(1, int64(2), uint16(3), float32(4.5), nil, []byte("b"), inner)
+	for _, m := range []encoding.BinaryMarshaler{l.(*List), Synchronized(l).(*SyncList)} {
+		b, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, decoded := range []ListInterface{new(List), new(SyncList)} {
+			if err = decoded.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); err != nil {
+				t.Error(err)
+			} else if equals, _ := decoded.Equals(l); !equals {
+				t.Errorf("Expected %v, got %v", l, decoded)
+			}
+		}
+	}
+	if v, _ := l.Get(1); v != int64(2) {
+		t.Errorf("Expected int64, got %T", v)
+	}
+}
+
+func TestGob(t *testing.T) {
+	inner, _ := MakeListFromValues(uint8(1))
+	l, _ := MakeListFromValues("a", inner, nil)
+	var buf bytes.Buffer
+	values := []interface{}{l, Synchronized(l)}
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded []interface{}
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(decoded) != 2 {
+		t.Fatalf("Expected 2 values, got %v", decoded)
+	} else if d, ok := decoded[0].(*List); !ok {
+		t.Errorf("Expected list, got %T", decoded[0])
+	} else if equals, _ := d.Equals(l); !equals {
+		t.Errorf("Expected %v, got %v", l, d)
+	} else if _, ok := decoded[1].(*SyncList); !ok {
+		t.Errorf("Expected synchronized list, got %T", decoded[1])
+	}
+}
@@ -1,6 +1,6 @@
 package list
 
-import "github.com/dynago/dg/internal/iterable"
+import "github.com/dynago/dg/iterable"
 
 // ListInterface is the interface which defines whether a struct is a list or not.
 type ListInterface interface {
@@ -8,6 +8,10 @@ type ListInterface interface {
 	Length() int
 	/* Return the next value in list. */
 	Iterate() <-chan interface{}
+	/* Call the function on each value in list until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the values in list. */
+	Iterator() iterable.Iterator
 
 	/* Test for membership in the list. */
 	Contains(interface{}) (bool, error)
@@ -20,24 +24,40 @@ type ListInterface interface {
 	Multiply(int) (ListInterface, error)
 	/* Return reversed list. */
 	Reverse() (ListInterface, error)
+	/* Sorts the list in place, stably, using the default ordering if the function is nil. */
+	Sort(func(a interface{}, b interface{}) bool) error
+	/* Sorts the list in place, stably, by the default ordering of the key of each value. */
+	SortBy(func(interface{}) interface{}) error
+	/* Return a new list with the values in the default ordering. */
+	Sorted() (ListInterface, error)
 
 	/* Returns the value at index. */
 	Get(int) (interface{}, error)
 	/* Returns the a list of values given range. */
 	Range(int, int) (ListInterface, error)
+	/* Returns every step-th value from start up to but not including stop, as in Python. */
+	Slice(int, int, int) (ListInterface, error)
 	/* Return first index of value. Returns -1 if not found. */
 	Index(interface{}) (int, error)
 	/* Return count of value. */
 	Count(interface{}) (int, error)
 
-	/* Inserts the value at index. */
+	/* Inserts the value before index, shifting later values right. Negative indexes count from the end. */
 	Insert(int, interface{}) error
+	/* Inserts the values in the iterable before index, shifting later values right. */
+	InsertAll(int, iterable.Iterable) error
+	/* Appends the values in the iterable to the end of the list. */
+	Extend(iterable.Iterable) error
 	/* Sets values in given range to the values in the iterable. */
 	Set(int, int, iterable.Iterable) error
+	/* Replaces the values in the slice start:stop:step with the values in the iterable, as in Python. */
+	SetSlice(int, int, int, iterable.Iterable) error
 	/* Remove first occurrence of the element from the list. */
 	Remove(interface{}) error
 	/* Removes range from the list. Takes two parameters: start (int), end (int: optional). */
 	Delete(int, ...int) error
+	/* Removes the values in the slice start:stop:step, as in Python. */
+	DeleteSlice(int, int, int) error
 	/* Appends element to the end of the list. */
 	Append(interface{}) error
 	/* Pop and return the last element from the list. */
@@ -0,0 +1,55 @@
+package list
+
+import "github.com/dynago/dg/internal/jsonvalue"
+
+func init() {
+	jsonvalue.MakeList = func(values []interface{}) (interface{}, error) {
+		return MakeListFromValues(values...)
+	}
+}
+
+/* MarshalJSON encodes the list as a JSON array. */
+func (l *List) MarshalJSON() ([]byte, error) {
+	return jsonvalue.EncodeArray(l.values, false)
+}
+
+/* UnmarshalJSON decodes a JSON array into the list. Nested arrays become lists and nested objects become dicts. */
+func (l *List) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, false)
+	if err != nil {
+		return err
+	}
+	l.values = values
+	return nil
+}
+
+/* MarshalJSON encodes a snapshot of the list as a JSON array. */
+func (l *SyncList) MarshalJSON() ([]byte, error) {
+	return jsonvalue.EncodeArray(l.snapshot(), false)
+}
+
+/* UnmarshalJSON decodes a JSON array into the list. Nested arrays become lists and nested objects become dicts. */
+func (l *SyncList) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, false)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.list == nil {
+		l.list = new(List)
+	}
+	l.list.Init()
+	for _, value := range values {
+		if err = l.list.Append(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package list
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	inner, _ := MakeListFromValues("a", nil)
+	l, _ := MakeListFromValues(1, 2.5, inner)
+	if b, err := json.Marshal(l); err != nil {
+		t.Error(err)
+	} else if string(b) != `[1,2.5,["a",null]]` {
+		t.Errorf("Expected array, got %s", b)
+	}
+	e, _ := MakeList()
+	if b, err := json.Marshal(e); err != nil || string(b) != `[]` {
+		t.Errorf("Expected empty array, got %s", b)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	l := new(List)
+	if err := json.Unmarshal([]byte(`[1, 2.5, 1e30, ["a", null], true]`), l); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inner, _ := MakeListFromValues("a", nil)
+	expected, _ := MakeListFromValues(1, 2.5, 1e30, inner, true)
+	if equals, err := l.Equals(expected); err != nil || !equals {
+		t.Errorf("Expected %v, got %v", expected, l)
+	} else if err := json.Unmarshal([]byte(`{"a": 1}`), l); err == nil {
+		t.Error("Expected error for object")
+	} else if err := json.Unmarshal([]byte(`[{"a": 1}]`), l); err == nil {
+		t.Error("Expected error for object without the dict package")
+	}
+}
+
+func TestSynchronizedJSON(t *testing.T) {
+	l, _ := MakeListFromValues(1, "b")
+	s := Synchronized(l)
+	b, err := json.Marshal(s)
+	if err != nil || string(b) != `[1,"b"]` {
+		t.Errorf("Expected array, got %s", b)
+	}
+	decoded := new(SyncList)
+	if err = json.Unmarshal(b, decoded); err != nil {
+		t.Error(err)
+	} else if equals, _ := decoded.Equals(l); !equals {
+		t.Errorf("Expected %v, got %v", l, decoded)
+	}
+}
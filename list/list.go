@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
 	"github.com/dynago/dg/internal/helpers"
-	"github.com/dynago/dg/internal/iterable"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/order"
 )
 
 // List is a dynamic list structure.
@@ -19,22 +22,29 @@ func (l *List) Length() int {
 	return len(l.values)
 }
 
-/* Iterate returns the next value in list. */
+/* Iterate returns the next value in list. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
 func (l *List) Iterate() <-chan interface{} {
-	c := make(chan interface{})
-	go func() {
-		for _, v := range l.values {
-			c <- v
+	return iterable.Channel(l)
+}
+
+/* ForEach calls the function on each value in list until it returns false. */
+func (l *List) ForEach(fn func(interface{}) bool) {
+	for _, v := range l.values {
+		if !fn(v) {
+			return
 		}
-		close(c)
-	}()
-	return c
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the values in list. */
+func (l *List) Iterator() iterable.Iterator {
+	return iterable.FromSlice(append([]interface{}(nil), l.values...))
 }
 
 /* Contains tests for membership in the list. */
 func (l *List) Contains(value interface{}) (bool, error) {
 	for _, v := range l.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			return true, nil
 		}
 	}
@@ -51,21 +61,54 @@ func (l *List) Equals(other ListInterface) (bool, error) {
 		if err != nil {
 			return false, err
 		}
-		if lv != ov {
+		if !equality.Equal(lv, ov) {
 			return false, nil
 		}
 	}
 	return true, nil
 }
 
+/* EqualTo returns true if the other value is a list with equal elements. */
+func (l *List) EqualTo(other interface{}) bool {
+	o, ok := other.(ListInterface)
+	if !ok {
+		return false
+	}
+	equals, err := l.Equals(o)
+	return err == nil && equals
+}
+
+/* CompareTo compares the list with another list element by element, as in order.Compare. A list which is a prefix of the other is before it. */
+func (l *List) CompareTo(other interface{}) (int, error) {
+	o, ok := other.(ListInterface)
+	if !ok {
+		return 0, &order.IncomparableError{A: l, B: other}
+	}
+	o, err := unwrapList(o)
+	if err != nil {
+		return 0, err
+	}
+	for i := 0; i < len(l.values) && i < o.Length(); i++ {
+		value, err := o.Get(i)
+		if err != nil {
+			return 0, err
+		}
+		if c, err := order.Compare(l.values[i], value); err != nil || c != 0 {
+			return c, err
+		}
+	}
+	return order.Compare(len(l.values), o.Length())
+}
+
 /* Concatenate returns concatenation of two lists together. */
 func (l *List) Concatenate(other ListInterface) (ListInterface, error) {
 	output := new(List)
 	output.Init()
-	output.values = l.values
-	for value := range other.Iterate() {
+	output.values = append(make([]interface{}, 0, len(l.values)+other.Length()), l.values...)
+	other.ForEach(func(value interface{}) bool {
 		output.values = append(output.values, value)
-	}
+		return true
+	})
 	return output, nil
 }
 
@@ -90,16 +133,17 @@ func (l *List) Reverse() (ListInterface, error) {
 	return output, nil
 }
 
-/* Get returns the value at index. */
+/* Get returns the value at index. Negative indexes count from the end of the list. */
 func (l *List) Get(i int) (interface{}, error) {
-	if i >= len(l.values) || i < 0 {
-		return nil, fmt.Errorf("Index i out of range of list")
+	j, ok := helpers.ElementIndex(i, len(l.values))
+	if !ok {
+		return nil, &errs.IndexError{Index: i, Length: len(l.values), Structure: "list"}
 	}
 
-	return l.values[i], nil
+	return l.values[j], nil
 }
 
-/* Range returns the a list of values given range. */
+/* Range returns the a list of values given range. Negative indexes count from the end of the list. */
 func (l *List) Range(start int, end int) (ListInterface, error) {
 	start = helpers.ValidIndex(start, len(l.values))
 	end = helpers.ValidIndex(end, len(l.values))
@@ -112,10 +156,23 @@ func (l *List) Range(start int, end int) (ListInterface, error) {
 	return output, nil
 }
 
+/* Slice returns a list of every step-th value from start up to but not including stop, with the same semantics as Python's l[start:stop:step]. Negative indexes count from the end of the list, and math.MinInt or math.MaxInt can be used for an open bound. */
+func (l *List) Slice(start int, stop int, step int) (ListInterface, error) {
+	first, count, err := helpers.SliceIndices(start, stop, step, len(l.values))
+	if err != nil {
+		return nil, err
+	}
+	output := &List{values: make([]interface{}, count)}
+	for k := range output.values {
+		output.values[k] = l.values[first+k*step]
+	}
+	return output, nil
+}
+
 /* Index returns first index of value. Returns -1 if not found. */
 func (l *List) Index(value interface{}) (int, error) {
 	for i, v := range l.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			return i, nil
 		}
 	}
@@ -126,46 +183,83 @@ func (l *List) Index(value interface{}) (int, error) {
 func (l *List) Count(value interface{}) (int, error) {
 	count := 0
 	for _, v := range l.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			count += 1
 		}
 	}
 	return count, nil
 }
 
-/* Insert inserts the value at index. */
+/* Insert inserts the value before index i, shifting later values right. Negative indexes count from the end of the list, and indexes out of range insert at the start or end. */
 func (l *List) Insert(i int, value interface{}) error {
-	if i >= len(l.values) || i < 0 {
-		return fmt.Errorf("Index i out of range of list")
-	}
+	i = helpers.ValidIndex(i, len(l.values))
+	l.values = append(l.values, nil)
+	copy(l.values[i+1:], l.values[i:])
 	l.values[i] = value
 	return nil
 }
 
-/* Set sets values in given range to the values in the iterable. */
+/* InsertAll inserts the values in the iterable before index i, shifting later values right. Indexes are treated as in Insert. */
+func (l *List) InsertAll(i int, it iterable.Iterable) error {
+	values := collect(it)
+	i = helpers.ValidIndex(i, len(l.values))
+	l.values = append(l.values[:i], append(values, l.values[i:]...)...)
+	return nil
+}
+
+/* Extend appends the values in the iterable to the end of the list. */
+func (l *List) Extend(it iterable.Iterable) error {
+	return l.InsertAll(len(l.values), it)
+}
+
+/* Set sets values in given range to the values in the iterable. Negative indexes count from the end of the list. */
 func (l *List) Set(start int, end int, it iterable.Iterable) error {
 	if len(l.values) > 0 {
-		s := helpers.ValidIndex(start, len(l.values))
-		if s != start {
-			return fmt.Errorf("Index start out of range of list")
+		first, last := start, end
+		if first < 0 {
+			first += len(l.values)
+		}
+		if first < 0 || first > len(l.values) {
+			return &errs.IndexError{Index: start, Length: len(l.values), Structure: "list"}
 		}
-		e := helpers.ValidIndex(end, len(l.values))
-		if e != end {
-			return fmt.Errorf("Index end out of range of list")
+		if last < 0 {
+			last += len(l.values)
+		}
+		if last < 0 || last > len(l.values) {
+			return &errs.IndexError{Index: end, Length: len(l.values), Structure: "list"}
 		}
 
-		c := it.Iterate()
-		for i := start; i < end; i++ {
-			val, ok := <-c
-			if !ok {
-				return fmt.Errorf("Iterable has no more values")
-			} else {
-				l.values[i] = val
+		iter := iterable.Iter(it)
+		defer iter.Close()
+		for i := first; i < last; i++ {
+			if !iter.Next() {
+				return &errs.LengthMismatchError{Length: i - first, Expected: last - first, Of: "values"}
 			}
+			l.values[i] = iter.Value()
 		}
 		return nil
 	}
-	return fmt.Errorf("Cannot set on empty list")
+	return &errs.EmptyError{Op: "set on", Structure: "list"}
+}
+
+/* SetSlice replaces the values in the slice start:stop:step with the values in the iterable, with the same semantics as Python's l[start:stop:step] = it. If step is 1 the list grows or shrinks to fit the values, otherwise there must be as many values as the slice has elements. */
+func (l *List) SetSlice(start int, stop int, step int, it iterable.Iterable) error {
+	values := collect(it)
+	first, count, err := helpers.SliceIndices(start, stop, step, len(l.values))
+	if err != nil {
+		return err
+	}
+	if step == 1 {
+		l.values = append(l.values[:first], append(values, l.values[first+count:]...)...)
+		return nil
+	}
+	if len(values) != count {
+		return &errs.LengthMismatchError{Length: len(values), Expected: count, Of: "values"}
+	}
+	for k, value := range values {
+		l.values[first+k*step] = value
+	}
+	return nil
 }
 
 /* Remove removes first occurrence of the element from the list. */
@@ -182,7 +276,7 @@ func (l *List) Remove(value interface{}) error {
 	return nil
 }
 
-/* Delete removes range from the list. Takes two parameters: start (int), end (int: optional). */
+/* Delete removes range from the list. Takes two parameters: start (int), end (int: optional). Negative indexes count from the end of the list. */
 func (l *List) Delete(start int, end ...int) error {
 	var s, e int
 
@@ -202,6 +296,28 @@ func (l *List) Delete(start int, end ...int) error {
 	return nil
 }
 
+/* DeleteSlice removes the values in the slice start:stop:step, with the same semantics as Python's del l[start:stop:step]. */
+func (l *List) DeleteSlice(start int, stop int, step int) error {
+	first, count, err := helpers.SliceIndices(start, stop, step, len(l.values))
+	if err != nil || count == 0 {
+		return err
+	}
+	if step < 0 {
+		first, step = first+(count-1)*step, -step
+	}
+	kept := l.values[:first]
+	for i := first; i < len(l.values); i++ {
+		if (i-first)%step != 0 || (i-first)/step >= count {
+			kept = append(kept, l.values[i])
+		}
+	}
+	for i := len(kept); i < len(l.values); i++ {
+		l.values[i] = nil
+	}
+	l.values = kept
+	return nil
+}
+
 /* Append appends element to the end of the list. */
 func (l *List) Append(value interface{}) error {
 	l.values = append(l.values, value)
@@ -215,7 +331,7 @@ func (l *List) Pop() (interface{}, error) {
 		l.values = l.values[:len(l.values)-1]
 		return value, nil
 	}
-	return nil, fmt.Errorf("Cannot pop from empty list")
+	return nil, &errs.EmptyError{Op: "pop from", Structure: "list"}
 }
 
 /* Clear clears all elements from the list. */
@@ -240,6 +356,16 @@ func (l *List) String() string {
 	return output
 }
 
+/* collect returns the values in the iterable. */
+func collect(it iterable.Iterable) []interface{} {
+	values := make([]interface{}, 0)
+	iterable.ForEach(it, func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
 /* Init initializes the list. */
 func (l *List) Init() {
 	l.values = make([]interface{}, 0)
@@ -250,10 +376,10 @@ func MakeList(it ...iterable.Iterable) (ListInterface, error) {
 	output := new(List)
 	output.Init()
 	if len(it) > 0 {
-		i := it[0]
-		for val := range i.Iterate() {
+		iterable.ForEach(it[0], func(val interface{}) bool {
 			output.values = append(output.values, val)
-		}
+			return true
+		})
 	}
 	return output, nil
 }
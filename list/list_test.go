@@ -1,8 +1,13 @@
 package list
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"runtime"
 	"testing"
+
+	"github.com/dynago/dg/errs"
 )
 
 func printChecker(str string, values []string) bool {
@@ -274,10 +279,16 @@ func TestGet(t *testing.T) {
 		t.Error(err2)
 	}
 
-	if _, err := s1.Get(-1); err == nil {
+	if _, err := s1.Get(-4); err == nil {
 		t.Fatalf("Was expecting index to be out of range")
 	}
 
+	if val, err := s1.Get(-1); err != nil {
+		t.Error(err)
+	} else if val != "hello" {
+		t.Fatalf("Got %s, was expecting hello", val)
+	}
+
 	if val, err := s1.Get(0); err != nil {
 		t.Error(err)
 	} else if val != 1 {
@@ -296,8 +307,11 @@ func TestGet(t *testing.T) {
 		t.Fatalf("Got %s, was expecting hello", val)
 	}
 
-	if _, err := s1.Get(3); err == nil {
-		t.Fatalf("Was expecting index to be out of range")
+	var indexErr *errs.IndexError
+	if _, err := s1.Get(3); !errors.As(err, &indexErr) {
+		t.Fatalf("Got %v, was expecting an IndexError", err)
+	} else if indexErr.Index != 3 || indexErr.Length != 3 || !errors.Is(err, errs.ErrIndexOutOfRange) {
+		t.Fatalf("Got %+v, was expecting index 3 of length 3", indexErr)
 	}
 
 	if _, err := s2.Get(0); err == nil {
@@ -336,7 +350,13 @@ func TestRange(t *testing.T) {
 
 	if s, err := s1.Range(-1, 4); err != nil {
 		t.Error(err)
-	} else if ok := printChecker(s.String(), []string{"1", "2.2", "hello"}); !ok {
+	} else if ok := printChecker(s.String(), []string{"hello"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Range(-5, -1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "2.2"}); !ok {
 		t.Fatalf("Got %s, which was unexpected", s.String())
 	}
 
@@ -444,28 +464,68 @@ func TestInsert(t *testing.T) {
 
 	if err := s1.Insert(0, 2); err != nil {
 		t.Error(err)
-	} else if ok := printChecker(s1.String(), []string{"2", "2.2", "hello"}); !ok {
+	} else if ok := printChecker(s1.String(), []string{"2", "1", "2.2", "hello"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.Insert(4, 3); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"2", "1", "2.2", "hello", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.Insert(-1, 4); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"2", "1", "2.2", "hello", "4", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.Insert(100, 5); err != nil {
+		t.Error(err)
+	} else if err := s1.Insert(-100, 0); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"0", "2", "1", "2.2", "hello", "4", "3", "5"}); !ok {
 		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
 
-	if err := s1.Insert(1, 2); err != nil {
+	if err := s2.Insert(0, 2); err != nil {
 		t.Error(err)
-	} else if ok := printChecker(s1.String(), []string{"2", "2", "hello"}); !ok {
+	} else if ok := printChecker(s2.String(), []string{"2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s2.String())
+	}
+}
+
+func TestInsertAll(t *testing.T) {
+	s1, _ := MakeListFromValues(1, 2)
+	s2, _ := MakeListFromValues("a", "b")
+
+	if err := s1.InsertAll(1, s2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"1", "a", "b", "2"}); !ok {
 		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
 
-	if err := s1.Insert(2, 2); err != nil {
+	if err := s1.InsertAll(-1, s1); err != nil {
 		t.Error(err)
-	} else if ok := printChecker(s1.String(), []string{"2", "2", "2"}); !ok {
+	} else if ok := printChecker(s1.String(), []string{"1", "a", "b", "1", "a", "b", "2", "2"}); !ok {
 		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
 
-	if err := s1.Insert(3, 2); err == nil {
-		t.Fatalf("Was expecting error")
+	if err := s2.Extend(s2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s2.String(), []string{"a", "b", "a", "b"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s2.String())
 	}
 
-	if err := s2.Insert(0, 2); err == nil {
-		t.Fatalf("Was expecting error")
+	s3 := Synchronized(s2)
+	if err := s3.Extend(s3); err != nil {
+		t.Error(err)
+	} else if s3.Length() != 8 {
+		t.Fatalf("Got %s, which was unexpected", s3.String())
+	} else if err := s3.InsertAll(0, s1); err != nil {
+		t.Error(err)
+	} else if s3.Length() != 16 {
+		t.Fatalf("Got %s, which was unexpected", s3.String())
 	}
 }
 
@@ -480,7 +540,7 @@ func TestSet(t *testing.T) {
 	}
 
 	list := listTester{input}
-	if err := s1.Set(-1, 1, &list); err == nil {
+	if err := s1.Set(-4, 1, &list); err == nil {
 		t.Fatalf("Expected range error")
 	}
 
@@ -500,8 +560,17 @@ func TestSet(t *testing.T) {
 		t.Fatalf("Expected range error")
 	}
 
-	if err := s1.Set(0, 3, &list); err == nil {
-		t.Fatalf("Expected iterable error")
+	var lengthErr *errs.LengthMismatchError
+	if err := s1.Set(0, 3, &list); !errors.As(err, &lengthErr) {
+		t.Fatalf("Got %v, was expecting a LengthMismatchError", err)
+	} else if lengthErr.Length != 2 || lengthErr.Expected != 3 {
+		t.Fatalf("Got %+v, was expecting 2 values of 3", lengthErr)
+	}
+
+	if err := s1.Set(-2, -1, &list); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"-1", "-1", "-2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
 }
 
@@ -569,12 +638,114 @@ func TestDelete(t *testing.T) {
 		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
 
-	values = []string{}
+	values = []string{"1"}
 	if err := s1.Delete(-1, 4); err != nil {
 		t.Error(err)
 	} else if ok := printChecker(s1.String(), values); !ok {
 		t.Fatalf("Got %s, which was unexpected", s1.String())
 	}
+
+	values = []string{}
+	if err := s1.Delete(-5, 4); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+}
+
+func TestSlice(t *testing.T) {
+	s1, err1 := MakeListFromValues(0, 1, 2, 3, 4, 5)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	if s, err := s1.Slice(1, 5, 2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Slice(math.MaxInt, math.MinInt, -1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"5", "4", "3", "2", "1", "0"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Slice(-2, 0, -2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"4", "2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Slice(4, 1, 1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if _, err := s1.Slice(0, 6, 0); err == nil {
+		t.Fatalf("Expected step error")
+	}
+}
+
+func TestSetSlice(t *testing.T) {
+	s1, err1 := MakeListFromValues(0, 1, 2, 3, 4, 5)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	list := listTester{a: []interface{}{"a", "b", "c"}}
+	if err := s1.SetSlice(0, 6, 2, &list); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"a", "1", "b", "3", "c", "5"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.SetSlice(1, 2, 1, &list); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"a", "a", "b", "c", "b", "3", "c", "5"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.SetSlice(-1, 0, -3, &list); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"a", "c", "b", "c", "b", "3", "c", "a"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	var lengthErr *errs.LengthMismatchError
+	if err := s1.SetSlice(0, 8, 2, &list); !errors.As(err, &lengthErr) {
+		t.Fatalf("Got %v, was expecting a LengthMismatchError", err)
+	} else if lengthErr.Length != 3 || lengthErr.Expected != 4 || !errors.Is(err, errs.ErrLengthMismatch) {
+		t.Fatalf("Got %+v, was expecting 3 values of 4", lengthErr)
+	}
+
+	if err := s1.SetSlice(0, 8, 0, &list); err == nil {
+		t.Fatalf("Expected step error")
+	}
+}
+
+func TestDeleteSlice(t *testing.T) {
+	s1, err1 := MakeListFromValues(0, 1, 2, 3, 4, 5)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	if err := s1.DeleteSlice(0, 6, 2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"1", "3", "5"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.DeleteSlice(-1, -3, -1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), []string{"1"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.DeleteSlice(0, 1, 0); err == nil {
+		t.Fatalf("Expected step error")
+	}
 }
 
 func TestAppend(t *testing.T) {
@@ -614,8 +785,8 @@ func TestPop(t *testing.T) {
 		t.Fatalf("Was expecting popped value to be 1, got %s", value)
 	}
 
-	if _, err := s.Pop(); err == nil {
-		t.Fatalf("Should not be able to pop from empty list")
+	if _, err := s.Pop(); !errors.Is(err, errs.ErrEmpty) {
+		t.Fatalf("Got %v, was expecting ErrEmpty from an empty list", err)
 	}
 }
 
@@ -642,3 +813,122 @@ func TestClear(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestDeepEquality(t *testing.T) {
+	inner1, err := MakeListFromValues(1, "a")
+	if err != nil {
+		t.Error(err)
+	}
+	inner2, err := MakeListFromValues(1, "a")
+	if err != nil {
+		t.Error(err)
+	}
+
+	s1, err := MakeListFromValues([]int{1, 2}, map[string]int{"a": 1}, inner1)
+	if err != nil {
+		t.Error(err)
+	}
+	s2, err := MakeListFromValues([]int{1, 2}, map[string]int{"a": 1}, inner2)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if contains, err := s1.Contains([]int{1, 2}); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The list does not contain [1 2] when it should")
+	}
+
+	if i, err := s1.Index(inner2); err != nil {
+		t.Error(err)
+	} else if i != 2 {
+		t.Fatalf("Got %d, was expecting 2", i)
+	}
+
+	if i, err := s1.Count(map[string]int{"a": 1}); err != nil {
+		t.Error(err)
+	} else if i != 1 {
+		t.Fatalf("Got %d, was expecting 1", i)
+	}
+
+	if equals, err := s1.Equals(s2); err != nil {
+		t.Error(err)
+	} else if equals == false {
+		t.Fatal("s1 should be equal to s2, but it is not")
+	}
+
+	if err := s1.Remove(inner2); err != nil {
+		t.Error(err)
+	}
+	if s1.Length() != 2 {
+		t.Fatalf("Expected the length of the list to be 2, got %d", s1.Length())
+	}
+}
+
+func TestForEach(t *testing.T) {
+	s, err := MakeListFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	values := make([]interface{}, 0)
+	s.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return len(values) < 2
+	})
+	if ok := printChecker(fmt.Sprint(values), []string{"1", "2.2"}); !ok {
+		t.Fatalf("Got %v, which was unexpected", values)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s, err := MakeListFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	iter := s.Iterator()
+	defer iter.Close()
+	values := make([]interface{}, 0)
+	for iter.Next() {
+		values = append(values, iter.Value())
+	}
+	if ok := printChecker(fmt.Sprint(values), []string{"1", "2.2", "hello"}); !ok {
+		t.Fatalf("Got %v, which was unexpected", values)
+	}
+	if iter.Next() {
+		t.Fatal("Iterator should be exhausted, but it is not")
+	}
+
+	snapshot := s.Iterator()
+	defer snapshot.Close()
+	other, _ := MakeListFromValues("x")
+	if err := s.SetSlice(0, 3, 1, other); err != nil {
+		t.Error(err)
+	}
+	values = values[:0]
+	for snapshot.Next() {
+		values = append(values, snapshot.Value())
+	}
+	if ok := printChecker(fmt.Sprint(values), []string{"1", "2.2", "hello"}); !ok {
+		t.Fatalf("Got %v, was expecting the values from before SetSlice", values)
+	}
+}
+
+func TestSetDoesNotLeak(t *testing.T) {
+	s, err := MakeListFromValues(1, 2, 3)
+	if err != nil {
+		t.Error(err)
+	}
+	list := listTester{[]interface{}{-1, -2, -3, -4}}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		if err := s.Set(0, 1, &list); err != nil {
+			t.Error(err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("Expected no more than %d goroutines, got %d", before, after)
+	}
+}
@@ -0,0 +1,77 @@
+package list
+
+import (
+	"sort"
+
+	"github.com/dynago/dg/order"
+)
+
+/* Sort sorts the list in place, keeping equal values in their original order. If less is nil the values are sorted in the default ordering of order.Compare. The list is left unchanged if it contains values with no default ordering. */
+func (l *List) Sort(less func(a interface{}, b interface{}) bool) error {
+	values, err := sortValues(l.values, less)
+	if err != nil {
+		return err
+	}
+	l.values = values
+	return nil
+}
+
+/* SortBy sorts the list in place by the key of each value, in the default ordering of the keys. The key function is called once per value, and equal keys keep their original order. */
+func (l *List) SortBy(key func(interface{}) interface{}) error {
+	keys := make([]interface{}, len(l.values))
+	for i, value := range l.values {
+		keys[i] = key(value)
+	}
+	values, err := sortIndexes(l.values, func(i int, j int) (bool, error) {
+		return order.Less(keys[i], keys[j])
+	})
+	if err != nil {
+		return err
+	}
+	l.values = values
+	return nil
+}
+
+/* Sorted returns a new list with the values of the list in the default ordering. */
+func (l *List) Sorted() (ListInterface, error) {
+	values, err := sortValues(l.values, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &List{values: values}, nil
+}
+
+/* sortValues returns a stably sorted copy of the values, using the default ordering if less is nil. */
+func sortValues(values []interface{}, less func(a interface{}, b interface{}) bool) ([]interface{}, error) {
+	return sortIndexes(values, func(i int, j int) (bool, error) {
+		if less == nil {
+			return order.Less(values[i], values[j])
+		}
+		return less(values[i], values[j]), nil
+	})
+}
+
+/* sortIndexes returns a copy of the values stably sorted by comparing their indexes with less, stopping at the first error. */
+func sortIndexes(values []interface{}, less func(i int, j int) (bool, error)) ([]interface{}, error) {
+	indexes := make([]int, len(values))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	var err error
+	sort.SliceStable(indexes, func(a int, b int) bool {
+		if err != nil {
+			return false
+		}
+		var ok bool
+		ok, err = less(indexes[a], indexes[b])
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := make([]interface{}, len(values))
+	for i, j := range indexes {
+		output[i] = values[j]
+	}
+	return output, nil
+}
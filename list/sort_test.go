@@ -0,0 +1,178 @@
+package list
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dynago/dg/order"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestSort(t *testing.T) {
+	s1, err1 := MakeListFromValues(3, "b", 1.5, nil, true, uint8(2), "a", false, int64(-1))
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	values := []string{"<nil>", "false", "true", "-1", "1.5", "2", "3", "a", "b"}
+	if err := s1.Sort(nil); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	values = []string{"b", "a", "3", "2", "1.5", "-1", "true", "false", "<nil>"}
+	if err := s1.Sort(func(a interface{}, b interface{}) bool {
+		less, _ := order.Less(b, a)
+		return less
+	}); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	s2, err2 := MakeListFromValues("bb", "a", "cc", "d")
+	if err2 != nil {
+		t.Error(err2)
+	}
+
+	values = []string{"a", "d", "bb", "cc"}
+	if err := s2.Sort(func(a interface{}, b interface{}) bool {
+		return len(a.(string)) < len(b.(string))
+	}); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s2.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s2.String())
+	}
+
+	s3, err3 := MakeListFromValues(1, []int{1}, 0)
+	if err3 != nil {
+		t.Error(err3)
+	}
+
+	values = []string{"1", "[1]", "0"}
+	if err := s3.Sort(nil); err == nil {
+		t.Fatalf("Expected ordering error")
+	} else if ok := printChecker(s3.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s3.String())
+	}
+}
+
+func TestSortNumbers(t *testing.T) {
+	s1, err1 := MakeListFromValues(uint64(math.MaxUint64), math.Inf(1), int64(math.MaxInt64), float32(0.5), math.NaN(), int8(-128), math.Inf(-1), 0)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	values := []string{"NaN", "-Inf", "-128", "0", "0.5", "9223372036854775807", "18446744073709551615", "+Inf"}
+	if err := s1.Sort(nil); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	s2, err2 := MakeListFromValues(1.0, 1, uint(1), float32(1))
+	if err2 != nil {
+		t.Error(err2)
+	}
+
+	if err := s2.Sort(nil); err != nil {
+		t.Error(err)
+	} else if ok := s2.(*List).EqualTo(&List{values: []interface{}{1.0, 1, uint(1), float32(1)}}); !ok {
+		t.Fatalf("Got %s, was expecting the original order", s2.String())
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	t1, _ := tuple.MakeTupleFromValues(1, "b")
+	t2, _ := tuple.MakeTupleFromValues(1, "a")
+	t3, _ := tuple.MakeTupleFromValues(0, "z", 1)
+	t4, _ := tuple.MakeTupleFromValues(1)
+
+	s1, err1 := MakeListFromValues(t1, t2, t3, t4)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	values := []string{"(0 z 1)", "(1)", "(1 a)", "(1 b)"}
+	if err := s1.SortBy(func(value interface{}) interface{} {
+		return value
+	}); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s1.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	s2, err2 := MakeListFromValues("Banana", "apple", "Cherry", "APPLE")
+	if err2 != nil {
+		t.Error(err2)
+	}
+
+	values = []string{"apple", "APPLE", "Banana", "Cherry"}
+	if err := s2.SortBy(func(value interface{}) interface{} {
+		return strings.ToLower(value.(string))
+	}); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s2.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", s2.String())
+	}
+
+	if err := s2.SortBy(func(value interface{}) interface{} {
+		return map[string]int{value.(string): 1}
+	}); err == nil {
+		t.Fatalf("Expected ordering error")
+	}
+}
+
+func TestSorted(t *testing.T) {
+	s1, err1 := MakeListFromValues(3, 1, 2)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	if s, err := s1.Sorted(); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "2", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	} else if ok := printChecker(s1.String(), []string{"3", "1", "2"}); !ok {
+		t.Fatalf("Got %s, was expecting the list to be unchanged", s1.String())
+	}
+
+	if s, err := Synchronized(s1).Sorted(); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "2", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+}
+
+func TestCompareTo(t *testing.T) {
+	s1, _ := MakeListFromValues(1, "a")
+	s2, _ := MakeListFromValues(1, "b")
+	s3, _ := MakeListFromValues(1)
+	t1, _ := tuple.MakeTupleFromValues(1, "a")
+
+	if c, err := s1.(*List).CompareTo(s2); err != nil {
+		t.Error(err)
+	} else if c != -1 {
+		t.Fatalf("Got %d, was expecting -1", c)
+	}
+
+	if c, err := order.Compare(Synchronized(s1), s3); err != nil {
+		t.Error(err)
+	} else if c != 1 {
+		t.Fatalf("Got %d, was expecting 1", c)
+	}
+
+	if _, err := order.Compare(s1, t1); err == nil {
+		t.Fatalf("Expected incomparable error")
+	}
+
+	nested, _ := MakeListFromValues(s2, s1, s3)
+	values := []string{"[1]", "[1 a]", "[1 b]"}
+	if err := nested.Sort(nil); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(nested.String(), values); !ok {
+		t.Fatalf("Got %s, which was unexpected", nested.String())
+	}
+}
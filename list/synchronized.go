@@ -0,0 +1,300 @@
+package list
+
+import (
+	"sync"
+
+	"github.com/dynago/dg/iterable"
+)
+
+// SyncList is a list which is safe for concurrent use. Iteration works over a snapshot of the list.
+type SyncList struct {
+	mu   sync.RWMutex
+	list ListInterface
+}
+
+/* Length returns the number of elements in list. */
+func (l *SyncList) Length() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Length()
+}
+
+/* Iterate returns the next value in a snapshot of the list. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (l *SyncList) Iterate() <-chan interface{} {
+	return iterable.Channel(l)
+}
+
+/* ForEach calls the function on each value in a snapshot of the list until it returns false. */
+func (l *SyncList) ForEach(fn func(interface{}) bool) {
+	for _, v := range l.snapshot() {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the values in list. */
+func (l *SyncList) Iterator() iterable.Iterator {
+	return iterable.FromSlice(l.snapshot())
+}
+
+/* Contains tests for membership in the list. */
+func (l *SyncList) Contains(value interface{}) (bool, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Contains(value)
+}
+
+/* Equals returns true if the list has all elements in common with the other list. */
+func (l *SyncList) Equals(other ListInterface) (bool, error) {
+	other, err := unwrapList(other)
+	if err != nil {
+		return false, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Equals(other)
+}
+
+/* EqualTo returns true if the other value is a list with equal elements. */
+func (l *SyncList) EqualTo(other interface{}) bool {
+	o, ok := other.(ListInterface)
+	if !ok {
+		return false
+	}
+	equals, err := l.Equals(o)
+	return err == nil && equals
+}
+
+/* CompareTo compares a snapshot of the list with another list element by element, as in order.Compare. */
+func (l *SyncList) CompareTo(other interface{}) (int, error) {
+	return (&List{values: l.snapshot()}).CompareTo(other)
+}
+
+/* Concatenate returns concatenation of two lists together. */
+func (l *SyncList) Concatenate(other ListInterface) (ListInterface, error) {
+	other, err := unwrapList(other)
+	if err != nil {
+		return nil, err
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Concatenate(other)
+}
+
+/* Multiply returns list repeated n times. */
+func (l *SyncList) Multiply(n int) (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Multiply(n)
+}
+
+/* Reverse returns reversed list. */
+func (l *SyncList) Reverse() (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Reverse()
+}
+
+/* Sort sorts the list in place, stably, using the default ordering if less is nil. The list is locked while sorting, so less must not use the list. */
+func (l *SyncList) Sort(less func(a interface{}, b interface{}) bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Sort(less)
+}
+
+/* SortBy sorts the list in place, stably, by the default ordering of the key of each value. The list is locked while sorting, so key must not use the list. */
+func (l *SyncList) SortBy(key func(interface{}) interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.SortBy(key)
+}
+
+/* Sorted returns a new list with the values of the list in the default ordering. */
+func (l *SyncList) Sorted() (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Sorted()
+}
+
+/* Get returns the value at index. Negative indexes count from the end of the list. */
+func (l *SyncList) Get(i int) (interface{}, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Get(i)
+}
+
+/* Range returns the a list of values given range. Negative indexes count from the end of the list. */
+func (l *SyncList) Range(start int, end int) (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Range(start, end)
+}
+
+/* Slice returns a list of every step-th value from start up to but not including stop, with the same semantics as Python's l[start:stop:step]. */
+func (l *SyncList) Slice(start int, stop int, step int) (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Slice(start, stop, step)
+}
+
+/* Index returns first index of value. Returns -1 if not found. */
+func (l *SyncList) Index(value interface{}) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Index(value)
+}
+
+/* Count returns count of value. */
+func (l *SyncList) Count(value interface{}) (int, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.Count(value)
+}
+
+/* Insert inserts the value before index i, shifting later values right. Negative indexes count from the end of the list, and indexes out of range insert at the start or end. */
+func (l *SyncList) Insert(i int, value interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Insert(i, value)
+}
+
+/* InsertAll inserts the values in the iterable before index i, shifting later values right. Indexes are treated as in Insert. */
+func (l *SyncList) InsertAll(i int, it iterable.Iterable) error {
+	values, err := MakeList(it)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.InsertAll(i, values)
+}
+
+/* Extend appends the values in the iterable to the end of the list. */
+func (l *SyncList) Extend(it iterable.Iterable) error {
+	values, err := MakeList(it)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Extend(values)
+}
+
+/* Set sets values in given range to the values in the iterable. Negative indexes count from the end of the list. */
+func (l *SyncList) Set(start int, end int, it iterable.Iterable) error {
+	values, err := MakeList(it)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Set(start, end, values)
+}
+
+/* SetSlice replaces the values in the slice start:stop:step with the values in the iterable, with the same semantics as Python's l[start:stop:step] = it. */
+func (l *SyncList) SetSlice(start int, stop int, step int, it iterable.Iterable) error {
+	values, err := MakeList(it)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.SetSlice(start, stop, step, values)
+}
+
+/* Remove removes first occurrence of the element from the list. */
+func (l *SyncList) Remove(value interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Remove(value)
+}
+
+/* Delete removes range from the list. Takes two parameters: start (int), end (int: optional). Negative indexes count from the end of the list. */
+func (l *SyncList) Delete(start int, end ...int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Delete(start, end...)
+}
+
+/* DeleteSlice removes the values in the slice start:stop:step, with the same semantics as Python's del l[start:stop:step]. */
+func (l *SyncList) DeleteSlice(start int, stop int, step int) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.DeleteSlice(start, stop, step)
+}
+
+/* Append appends element to the end of the list. */
+func (l *SyncList) Append(value interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Append(value)
+}
+
+/* Pop pops and returns the last element from the list. */
+func (l *SyncList) Pop() (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Pop()
+}
+
+/* Clear clears all elements from the list. */
+func (l *SyncList) Clear() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Clear()
+}
+
+/* Copy creates a synchronized copy of the current list. */
+func (l *SyncList) Copy() (ListInterface, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	output, err := l.list.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return Synchronized(output), nil
+}
+
+/* String returns a string representation of the list. */
+func (l *SyncList) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.list.String()
+}
+
+/* Init initializes the list. */
+func (l *SyncList) Init() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.list == nil {
+		l.list = new(List)
+	}
+	l.list.Init()
+}
+
+/* snapshot returns a copy of the values in the list. */
+func (l *SyncList) snapshot() []interface{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	values := make([]interface{}, 0, l.list.Length())
+	l.list.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+/* unwrapList returns an unsynchronized copy of the list if it is synchronized, so that it can be read while another lock is held. */
+func unwrapList(l ListInterface) (ListInterface, error) {
+	s, ok := l.(*SyncList)
+	if !ok {
+		return l, nil
+	}
+	return MakeListFromValues(s.snapshot()...)
+}
+
+/* Synchronized returns a list which guards the given list with a read/write lock. The given list should not be used directly afterwards. */
+func Synchronized(l ListInterface) ListInterface {
+	return &SyncList{list: l}
+}
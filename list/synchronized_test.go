@@ -0,0 +1,85 @@
+package list
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronizedConcurrent(t *testing.T) {
+	l, err := MakeList()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	s := Synchronized(l)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if err := s.Append(j); err != nil {
+					t.Error(err)
+				}
+				s.ForEach(func(value interface{}) bool {
+					return value != nil
+				})
+				s.Count(j)
+			}
+		}()
+	}
+	wg.Wait()
+	if s.Length() != 400 {
+		t.Errorf("Expected 400 elements, got %d", s.Length())
+	}
+}
+
+func TestSynchronizedSnapshot(t *testing.T) {
+	l, _ := MakeListFromValues(1, 2, 3)
+	s := Synchronized(l)
+	count := 0
+	s.ForEach(func(value interface{}) bool {
+		count++
+		if err := s.Append(value); err != nil {
+			t.Error(err)
+		}
+		return true
+	})
+	if count != 3 {
+		t.Errorf("Expected to visit 3 values, visited %d", count)
+	} else if s.Length() != 6 {
+		t.Errorf("Expected 6 elements, got %v", s)
+	}
+}
+
+func TestSynchronizedSelf(t *testing.T) {
+	l, _ := MakeListFromValues(1, 2)
+	s := Synchronized(l)
+	if err := s.Set(0, 2, s); err != nil {
+		t.Error(err)
+	} else if equals, err := s.Equals(s); err != nil || !equals {
+		t.Error("Synchronized list is not equal to itself")
+	} else if c, err := s.Concatenate(s); err != nil || c.Length() != 4 {
+		t.Errorf("Expected concatenation of length 4, got %v", c)
+	}
+}
+
+func TestSynchronizedConcatenateCopies(t *testing.T) {
+	l, _ := MakeListFromValues(1, 2, 3, 4, 5)
+	s := Synchronized(l)
+	other, _ := MakeListFromValues(6)
+	c, err := s.Concatenate(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nine, _ := MakeListFromValues(9)
+	if err := c.Set(0, 1, nine); err != nil {
+		t.Error(err)
+	} else if value, _ := s.Get(0); value != 1 {
+		t.Fatalf("Got %v, was expecting the synchronized list not to change", value)
+	}
+	if c2, _ := s.Concatenate(nine); c2.String() != "[1 2 3 4 5 9]" {
+		t.Fatalf("Got %v, which was unexpected", c2)
+	} else if c.String() != "[9 2 3 4 5 6]" {
+		t.Fatalf("Got %v, was expecting the first result not to change", c)
+	}
+}
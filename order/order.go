@@ -0,0 +1,242 @@
+// Package order implements the total ordering used to sort and compare dynamic values in dg structures.
+package order
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Ordered is the interface implemented by types which order themselves against other values. Tuples and lists are Ordered.
+type Ordered interface {
+	/* Return -1, 0 or 1 as the value is less than, equal to or greater than the other value, or an IncomparableError. */
+	CompareTo(interface{}) (int, error)
+}
+
+// IncomparableError is the error returned when two values have no order.
+type IncomparableError struct {
+	A interface{}
+	B interface{}
+}
+
+/* Error returns a description of the values which could not be compared. */
+func (e *IncomparableError) Error() string {
+	return fmt.Sprintf("Cannot compare values of type %T and %T", e.A, e.B)
+}
+
+// Ranks of the kinds of value, in the order values of different kinds are sorted.
+const (
+	rankNil = iota
+	rankBool
+	rankNumber
+	rankString
+	rankBytes
+	rankTime
+	rankOrdered
+)
+
+/* Compare returns -1, 0 or 1 as a is less than, equal to or greater than b. Values of different kinds are ordered nil, bools, numbers, strings, byte slices, times and then Ordered values. Within a kind false is before true, numbers of any width are compared exactly by value with NaN before all other numbers, strings and byte slices are compared bytewise, times are compared chronologically, and Ordered values compare themselves; tuples and lists are compared element by element. It returns an *IncomparableError if either value is of any other type, or if an Ordered value cannot be compared with the other, such as a tuple with a list. */
+func Compare(a interface{}, b interface{}) (int, error) {
+	ra, oka := rank(a)
+	rb, okb := rank(b)
+	if !oka || !okb {
+		return 0, &IncomparableError{a, b}
+	}
+	if ra != rb {
+		return sign(ra - rb), nil
+	}
+	switch ra {
+	case rankBool:
+		x, y := a.(bool), b.(bool)
+		if x == y {
+			return 0, nil
+		} else if y {
+			return -1, nil
+		}
+		return 1, nil
+	case rankNumber:
+		return compareNumbers(a, b), nil
+	case rankString:
+		x, y := a.(string), b.(string)
+		if x < y {
+			return -1, nil
+		} else if x > y {
+			return 1, nil
+		}
+		return 0, nil
+	case rankBytes:
+		return bytes.Compare(a.([]byte), b.([]byte)), nil
+	case rankTime:
+		x, y := a.(time.Time), b.(time.Time)
+		if x.Before(y) {
+			return -1, nil
+		} else if x.After(y) {
+			return 1, nil
+		}
+		return 0, nil
+	case rankOrdered:
+		c, err := a.(Ordered).CompareTo(b)
+		return sign(c), err
+	}
+	return 0, nil
+}
+
+/* Less returns true if a is before b. It returns an error if the values cannot be compared. */
+func Less(a interface{}, b interface{}) (bool, error) {
+	c, err := Compare(a, b)
+	return c < 0, err
+}
+
+/* rank returns the rank of the kind of the value, and false if the value has no order. */
+func rank(value interface{}) (int, bool) {
+	switch value.(type) {
+	case nil:
+		return rankNil, true
+	case bool:
+		return rankBool, true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return rankNumber, true
+	case string:
+		return rankString, true
+	case []byte:
+		return rankBytes, true
+	case time.Time:
+		return rankTime, true
+	case Ordered:
+		return rankOrdered, true
+	}
+	return 0, false
+}
+
+func sign(i int) int {
+	if i < 0 {
+		return -1
+	} else if i > 0 {
+		return 1
+	}
+	return 0
+}
+
+/* number returns the value as exactly one of an int64, a uint64 or a float64. */
+func number(value interface{}) (int64, uint64, float64, int) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), 0, 0, 0
+	case int8:
+		return int64(v), 0, 0, 0
+	case int16:
+		return int64(v), 0, 0, 0
+	case int32:
+		return int64(v), 0, 0, 0
+	case int64:
+		return v, 0, 0, 0
+	case uint:
+		return 0, uint64(v), 0, 1
+	case uint8:
+		return 0, uint64(v), 0, 1
+	case uint16:
+		return 0, uint64(v), 0, 1
+	case uint32:
+		return 0, uint64(v), 0, 1
+	case uint64:
+		return 0, v, 0, 1
+	case uintptr:
+		return 0, uint64(v), 0, 1
+	case float32:
+		return 0, 0, float64(v), 2
+	}
+	return 0, 0, value.(float64), 2
+}
+
+/* compareNumbers compares numbers of any type exactly, without converting integers to floats. */
+func compareNumbers(a interface{}, b interface{}) int {
+	ia, ua, fa, ka := number(a)
+	ib, ub, fb, kb := number(b)
+	switch {
+	case ka == 0 && kb == 0:
+		return compareInts(ia, ib)
+	case ka == 1 && kb == 1:
+		return compareUints(ua, ub)
+	case ka == 0 && kb == 1:
+		if ia < 0 {
+			return -1
+		}
+		return compareUints(uint64(ia), ub)
+	case ka == 1 && kb == 0:
+		return -compareNumbers(b, a)
+	case ka == 2 && kb == 2:
+		return compareFloats(fa, fb)
+	case ka == 2 && kb == 0:
+		return compareFloatInt(fa, ib)
+	case ka == 2 && kb == 1:
+		return compareFloatUint(fa, ub)
+	}
+	return -compareNumbers(b, a)
+}
+
+func compareInts(a int64, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func compareUints(a uint64, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+/* compareFloats compares floats, treating NaN as equal to itself and less than any other number. */
+func compareFloats(a float64, b float64) int {
+	aNaN, bNaN := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case aNaN && bNaN:
+		return 0
+	case aNaN:
+		return -1
+	case bNaN:
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloatInt(f float64, i int64) int {
+	switch {
+	case math.IsNaN(f):
+		return -1
+	case f < math.MinInt64:
+		return -1
+	case f >= math.MaxInt64:
+		return 1
+	}
+	t := int64(f)
+	if c := compareInts(t, i); c != 0 {
+		return c
+	}
+	return compareFloats(f-float64(t), 0)
+}
+
+func compareFloatUint(f float64, u uint64) int {
+	switch {
+	case math.IsNaN(f), f < 0:
+		return -1
+	case f >= math.MaxUint64:
+		return 1
+	}
+	t := uint64(f)
+	if c := compareUints(t, u); c != 0 {
+		return c
+	}
+	return compareFloats(f-float64(t), 0)
+}
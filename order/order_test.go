@@ -0,0 +1,103 @@
+package order
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+type orderedTester struct {
+	name string
+}
+
+func (o orderedTester) CompareTo(other interface{}) (int, error) {
+	p, ok := other.(orderedTester)
+	if !ok {
+		return 0, &IncomparableError{o, other}
+	}
+	return strings.Compare(strings.ToLower(o.name), strings.ToLower(p.name)), nil
+}
+
+type otherTester int
+
+func (o otherTester) CompareTo(other interface{}) (int, error) {
+	return 0, &IncomparableError{o, other}
+}
+
+func TestCompareBasic(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		a        interface{}
+		b        interface{}
+		expected int
+	}{
+		{nil, nil, 0},
+		{nil, false, -1},
+		{false, true, -1},
+		{true, true, 0},
+		{true, -100, -1},
+		{1, 1.0, 0},
+		{int8(-1), uint64(0), -1},
+		{uint64(math.MaxUint64), int64(math.MaxInt64), 1},
+		{float32(0.5), 1, -1},
+		{1.5, uint(1), 1},
+		{-0.5, 0, -1},
+		{math.Inf(1), uint64(math.MaxUint64), 1},
+		{math.NaN(), math.Inf(-1), -1},
+		{math.NaN(), math.NaN(), 0},
+		{9007199254740993, 9007199254740992.0, 1},
+		{math.MaxFloat64, "a", -1},
+		{"a", "b", -1},
+		{"b", "ab", 1},
+		{"z", []byte("a"), -1},
+		{[]byte("ab"), []byte("a"), 1},
+		{[]byte("a"), now, -1},
+		{now, now.Add(time.Second), -1},
+		{now, orderedTester{"a"}, -1},
+		{orderedTester{"a"}, orderedTester{"A"}, 0},
+		{orderedTester{"b"}, orderedTester{"A"}, 1},
+	}
+	for _, test := range tests {
+		if c, err := Compare(test.a, test.b); err != nil {
+			t.Error(err)
+		} else if c != test.expected {
+			t.Fatalf("Got %d comparing %v and %v, was expecting %d", c, test.a, test.b, test.expected)
+		}
+		if c, err := Compare(test.b, test.a); err != nil {
+			t.Error(err)
+		} else if c != -test.expected {
+			t.Fatalf("Got %d comparing %v and %v, was expecting %d", c, test.b, test.a, -test.expected)
+		}
+	}
+}
+
+func TestLess(t *testing.T) {
+	if less, err := Less(1, 2.5); err != nil {
+		t.Error(err)
+	} else if !less {
+		t.Fatal("1 should be less than 2.5, but it is not")
+	}
+	if less, err := Less("a", "a"); err != nil {
+		t.Error(err)
+	} else if less {
+		t.Fatal("a should not be less than a, but it is")
+	}
+}
+
+func TestIncomparable(t *testing.T) {
+	tests := [][2]interface{}{
+		{map[string]int{}, 1},
+		{1, struct{}{}},
+		{[]int{1}, []int{2}},
+		{orderedTester{"a"}, otherTester(1)},
+	}
+	for _, test := range tests {
+		_, err := Compare(test[0], test[1])
+		var incomparable *IncomparableError
+		if !errors.As(err, &incomparable) {
+			t.Fatalf("Got %v comparing %v and %v, was expecting an IncomparableError", err, test[0], test[1])
+		}
+	}
+}
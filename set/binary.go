@@ -0,0 +1,141 @@
+package set
+
+import (
+	"encoding/gob"
+
+	"github.com/dynago/dg/codec"
+)
+
+func init() {
+	build := func(values []interface{}) (interface{}, error) {
+		return MakeSetFromValues(values...)
+	}
+	codec.RegisterContainer("set", (*Set)(nil), binaryElements, build)
+	codec.RegisterContainer("set", (*SyncSet)(nil), binaryElements, build)
+	codec.RegisterContainer("frozenset", (*FrozenSet)(nil), binaryElements, func(values []interface{}) (interface{}, error) {
+		return MakeFrozenSetFromValues(values...)
+	})
+	codec.RegisterContainer("sortedset", (*SortedSet)(nil), binaryElements, func(values []interface{}) (interface{}, error) {
+		return MakeSortedSetFromValues(values...)
+	})
+	gob.Register(new(Set))
+	gob.Register(new(FrozenSet))
+	gob.Register(new(SyncSet))
+	gob.Register(new(SortedSet))
+}
+
+/* MarshalBinary encodes the set, preserving the type of every element. */
+func (s *Set) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(s)
+}
+
+/* UnmarshalBinary decodes a set encoded by MarshalBinary into the set. */
+func (s *Set) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "set", "frozenset", "sortedset")
+	if err != nil {
+		return err
+	}
+	return fill(s, values)
+}
+
+/* GobEncode encodes the set for encoding/gob. */
+func (s *Set) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+/* GobDecode decodes the set for encoding/gob. */
+func (s *Set) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes the frozen set, preserving the type of every element. */
+func (f *FrozenSet) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(f)
+}
+
+/* UnmarshalBinary decodes a set encoded by MarshalBinary into the frozen set. */
+func (f *FrozenSet) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "set", "frozenset", "sortedset")
+	if err != nil {
+		return err
+	}
+	s := new(Set)
+	if err = fill(s, values); err != nil {
+		return err
+	}
+	f.set = s
+	return nil
+}
+
+/* GobEncode encodes the frozen set for encoding/gob. */
+func (f *FrozenSet) GobEncode() ([]byte, error) {
+	return f.MarshalBinary()
+}
+
+/* GobDecode decodes the frozen set for encoding/gob. */
+func (f *FrozenSet) GobDecode(data []byte) error {
+	return f.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes a snapshot of the set, preserving the type of every element. */
+func (s *SyncSet) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(s)
+}
+
+/* UnmarshalBinary decodes a set encoded by MarshalBinary into the set. */
+func (s *SyncSet) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "set", "frozenset", "sortedset")
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set == nil {
+		s.set = new(Set)
+	}
+	return fill(s.set, values)
+}
+
+/* GobEncode encodes the set for encoding/gob. */
+func (s *SyncSet) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+/* GobDecode decodes the set for encoding/gob. */
+func (s *SyncSet) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+/* MarshalBinary encodes the sorted set, preserving the type of every element. The comparison function is not encoded, so the set decodes ordered by order.Compare unless it is decoded into a set with its own comparison function. */
+func (s *SortedSet) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(s)
+}
+
+/* UnmarshalBinary decodes a set encoded by MarshalBinary into the sorted set. */
+func (s *SortedSet) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "set", "frozenset", "sortedset")
+	if err != nil {
+		return err
+	}
+	return fill(s, values)
+}
+
+/* GobEncode encodes the sorted set for encoding/gob. */
+func (s *SortedSet) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+/* GobDecode decodes the sorted set for encoding/gob. */
+func (s *SortedSet) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+/* binaryElements returns the elements of the set to encode. */
+func binaryElements(s interface{}) ([]interface{}, error) {
+	values := make([]interface{}, 0)
+	s.(SetInterface).ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values, nil
+}
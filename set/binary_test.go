@@ -0,0 +1,64 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/dynago/dg/codec"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestBinary(t *testing.T) {
+	tup, _ := tuple.MakeTupleFromValues(1, "a")
+	frozen, _ := MakeFrozenSetFromValues(int8(1))
+	s, _ := MakeSetFromValues(1, int64(1), uint8(1), 1.0, tup, frozen)
+	b, err := s.(*Set).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := new(Set)
+	if err = decoded.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if decoded.Length() != 6 {
+		t.Errorf("Expected 6 elements, got %v", decoded)
+	} else if equals, _ := decoded.Equals(s); !equals {
+		t.Errorf("Expected %v, got %v", s, decoded)
+	}
+	b, err = frozen.(*FrozenSet).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v, err := codec.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if _, ok := v.(*FrozenSet); !ok {
+		t.Errorf("Expected frozen set, got %T", v)
+	}
+	ss := new(SyncSet)
+	if err = ss.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if ok, _ := ss.Contains(int8(1)); !ok {
+		t.Errorf("Expected %v to contain int8(1)", ss)
+	}
+}
+
+func TestGob(t *testing.T) {
+	frozen, _ := MakeFrozenSetFromValues(int8(1))
+	s, _ := MakeSetFromValues("a", frozen)
+	var buf bytes.Buffer
+	values := map[string]SetInterface{"set": s, "frozen": frozen, "sync": Synchronized(s)}
+	if err := gob.NewEncoder(&buf).Encode(values); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded map[string]SetInterface
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if equals, _ := decoded["set"].Equals(s); !equals {
+		t.Errorf("Expected %v, got %v", s, decoded["set"])
+	} else if _, ok := decoded["frozen"].(*FrozenSet); !ok {
+		t.Errorf("Expected frozen set, got %T", decoded["frozen"])
+	} else if _, ok := decoded["sync"].(*SyncSet); !ok {
+		t.Errorf("Expected synchronized set, got %T", decoded["sync"])
+	}
+}
@@ -0,0 +1,178 @@
+package set
+
+import (
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/iterable"
+)
+
+// FrozenSet is an immutable set. Because its elements cannot change, it can be used as a dict key or as an element of another set.
+type FrozenSet struct {
+	set *Set
+}
+
+/* Get returns the value given a string representation of the bytes. */
+func (f *FrozenSet) Get(hash string) interface{} {
+	return f.set.Get(hash)
+}
+
+/* Length returns the number of elements in the frozen set. */
+func (f *FrozenSet) Length() int {
+	return f.set.Length()
+}
+
+/* Iterate returns the next key in the frozen set. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (f *FrozenSet) Iterate() <-chan interface{} {
+	return f.set.Iterate()
+}
+
+/* ForEach calls the function on each element in the frozen set until it returns false. */
+func (f *FrozenSet) ForEach(fn func(interface{}) bool) {
+	f.set.ForEach(fn)
+}
+
+/* Iterator returns an Iterator over the elements in the frozen set. */
+func (f *FrozenSet) Iterator() iterable.Iterator {
+	return f.set.Iterator()
+}
+
+/* Add returns an *errs.ReadOnlyError, as a frozen set cannot be modified. */
+func (f *FrozenSet) Add(value interface{}) error {
+	return &errs.ReadOnlyError{Op: "add to", Structure: "frozen set"}
+}
+
+/* Remove returns an *errs.ReadOnlyError, as a frozen set cannot be modified. */
+func (f *FrozenSet) Remove(value interface{}) error {
+	return &errs.ReadOnlyError{Op: "remove from", Structure: "frozen set"}
+}
+
+/* Combine returns an *errs.ReadOnlyError, as a frozen set cannot be modified. */
+func (f *FrozenSet) Combine(other SetInterface) error {
+	return &errs.ReadOnlyError{Op: "combine into", Structure: "frozen set"}
+}
+
+/* Pop returns an *errs.ReadOnlyError, as a frozen set cannot be modified. */
+func (f *FrozenSet) Pop() (interface{}, error) {
+	return nil, &errs.ReadOnlyError{Op: "pop from", Structure: "frozen set"}
+}
+
+/* Clear returns an *errs.ReadOnlyError, as a frozen set cannot be modified. */
+func (f *FrozenSet) Clear() error {
+	return &errs.ReadOnlyError{Op: "clear", Structure: "frozen set"}
+}
+
+/* Contains tests for membership in the frozen set. */
+func (f *FrozenSet) Contains(value interface{}) (bool, error) {
+	return f.set.Contains(value)
+}
+
+/* Disjoint returns true if the frozen set has no elements in common with the other set. */
+func (f *FrozenSet) Disjoint(other SetInterface) (bool, error) {
+	return f.set.Disjoint(other)
+}
+
+/* Equals returns true if the frozen set has all elements in common with the other set. */
+func (f *FrozenSet) Equals(other SetInterface) (bool, error) {
+	return f.set.Equals(other)
+}
+
+/* SupersetOf tests whether every element in the other set is in the frozen set. */
+func (f *FrozenSet) SupersetOf(other SetInterface) (bool, error) {
+	return f.set.SupersetOf(other)
+}
+
+/* SubsetOf tests whether every element in the frozen set is in the other set. */
+func (f *FrozenSet) SubsetOf(other SetInterface) (bool, error) {
+	return f.set.SubsetOf(other)
+}
+
+/* Intersection returns a new frozen set with elements common to the frozen set and all others. */
+func (f *FrozenSet) Intersection(other SetInterface) (SetInterface, error) {
+	output, err := f.set.Intersection(other)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(output.(*Set)), nil
+}
+
+/* SymmetricDifference returns a new frozen set with elements in either the frozen set or the other but not both. */
+func (f *FrozenSet) SymmetricDifference(other SetInterface) (SetInterface, error) {
+	output, err := f.set.SymmetricDifference(other)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(output.(*Set)), nil
+}
+
+/* Difference returns a new frozen set with elements in the frozen set that are not in the other set. */
+func (f *FrozenSet) Difference(other SetInterface) (SetInterface, error) {
+	output, err := f.set.Difference(other)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(output.(*Set)), nil
+}
+
+/* Union returns a new frozen set with elements from the frozen set and the other set. */
+func (f *FrozenSet) Union(other SetInterface) (SetInterface, error) {
+	output, err := f.set.Union(other)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(output.(*Set)), nil
+}
+
+/* Copy returns the frozen set itself, as it cannot be modified. */
+func (f *FrozenSet) Copy() (SetInterface, error) {
+	return f, nil
+}
+
+/* HashKey returns the bytes which identify the frozen set. The order of the elements does not change the key. */
+func (f *FrozenSet) HashKey() ([]byte, error) {
+	return hashKey(f.set)
+}
+
+/* EqualTo returns true if the other value is a set with the same elements. */
+func (f *FrozenSet) EqualTo(other interface{}) bool {
+	o, ok := other.(SetInterface)
+	if !ok {
+		return false
+	}
+	equals, err := f.Equals(o)
+	return err == nil && equals
+}
+
+/* String returns a string representation of the frozen set. */
+func (f *FrozenSet) String() string {
+	return f.set.String()
+}
+
+/* Init initializes the frozen set. It has no effect on a frozen set which is already initialized. */
+func (f *FrozenSet) Init() {
+	if f.set == nil {
+		f.set = new(Set)
+		f.set.Init()
+	}
+}
+
+/* freeze returns a frozen set which takes ownership of the set. */
+func freeze(s *Set) *FrozenSet {
+	return &FrozenSet{set: s}
+}
+
+/* MakeFrozenSet initializes a new frozen set object using an Iterable. */
+func MakeFrozenSet(it ...iterable.Iterable) (SetInterface, error) {
+	s, err := MakeSet(it...)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(s.(*Set)), nil
+}
+
+/* MakeFrozenSetFromValues initializes a new frozen set object using interface{} objects. */
+func MakeFrozenSetFromValues(values ...interface{}) (SetInterface, error) {
+	s, err := MakeSetFromValues(values...)
+	if err != nil {
+		return nil, err
+	}
+	return freeze(s.(*Set)), nil
+}
@@ -0,0 +1,122 @@
+package set
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestFrozenSetImmutable(t *testing.T) {
+	f, err := MakeFrozenSetFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	var readOnlyErr *errs.ReadOnlyError
+	if err := f.Add(3); !errors.As(err, &readOnlyErr) {
+		t.Fatalf("Got %v, was expecting a ReadOnlyError adding to frozen set", err)
+	} else if readOnlyErr.Op != "add to" || !errors.Is(err, errs.ErrReadOnly) {
+		t.Fatalf("Got %+v, was expecting add to", readOnlyErr)
+	}
+	if err := f.Remove(1); err == nil {
+		t.Fatal("Expected error removing from frozen set")
+	}
+	if _, err := f.Pop(); !errors.Is(err, errs.ErrReadOnly) {
+		t.Fatalf("Got %v, was expecting ErrReadOnly popping from frozen set", err)
+	}
+	if err := f.Clear(); err == nil {
+		t.Fatal("Expected error clearing frozen set")
+	}
+	if ok := printChecker(f.String(), []string{"1", "2.2", "hello"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", f.String())
+	}
+}
+
+func TestFrozenSetAlgebra(t *testing.T) {
+	f, err := MakeFrozenSetFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	s, err := MakeSetFromValues(2.2, 42)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if u, err := f.Union(s); err != nil {
+		t.Error(err)
+	} else if _, ok := u.(*FrozenSet); !ok {
+		t.Fatalf("Expected union to be a frozen set, got %T", u)
+	} else if ok := printChecker(u.String(), []string{"1", "2.2", "hello", "42"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", u.String())
+	}
+
+	if i, err := s.Intersection(f); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(i.String(), []string{"2.2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", i.String())
+	}
+
+	if equals, err := s.Equals(f); err != nil {
+		t.Error(err)
+	} else if equals == true {
+		t.Fatal("s and f should not be equal, but they are")
+	}
+}
+
+func TestFrozenSetHashKey(t *testing.T) {
+	f1, err := MakeFrozenSetFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+	f2, err := MakeFrozenSetFromValues("hello", 1, 2.2)
+	if err != nil {
+		t.Error(err)
+	}
+	f3, err := MakeFrozenSetFromValues(1, 2.2)
+	if err != nil {
+		t.Error(err)
+	}
+
+	s, err := MakeSetFromValues(f1, f2, f3)
+	if err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 2 {
+		t.Fatalf("Expected the length of the set to be 2, got %d", s.Length())
+	}
+
+	f4, err := MakeFrozenSetFromValues(2.2, 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if contains, err := s.Contains(f4); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The set does not contain (1 2.2) when it should")
+	}
+}
+
+func TestTupleElements(t *testing.T) {
+	t1, err := tuple.MakeTupleFromValues(1, "a")
+	if err != nil {
+		t.Error(err)
+	}
+	t2, err := tuple.MakeTupleFromValues(1, "a")
+	if err != nil {
+		t.Error(err)
+	}
+	t3, err := tuple.MakeTupleFromValues("a", 1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	s, err := MakeSetFromValues(t1, t2, t3)
+	if err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 2 {
+		t.Fatalf("Expected the length of the set to be 2, got %d", s.Length())
+	}
+}
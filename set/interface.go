@@ -1,11 +1,17 @@
 package set
 
+import "github.com/dynago/dg/iterable"
+
 // SetInterface is the interface which defines whether a struct is a set or not.
 type SetInterface interface {
 	/* Return the number of elements in set. */
 	Length() int
 	/* Return the next key in set. */
 	Iterate() <-chan interface{}
+	/* Call the function on each element in set until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the elements in set. */
+	Iterator() iterable.Iterator
 
 	/* Add element to the set. */
 	Add(interface{}) error
@@ -50,3 +56,15 @@ type SetInterface interface {
 	/* Initializes the set. */
 	Init()
 }
+
+// OrderedSetInterface is the interface implemented by sets which keep their elements in insertion order.
+type OrderedSetInterface interface {
+	SetInterface
+
+	/* Move the element to the end of the set. */
+	MoveToEnd(interface{}) error
+	/* Pop and return the least recently added element. */
+	PopFirst() (interface{}, error)
+	/* Pop and return the most recently added element. */
+	PopLast() (interface{}, error)
+}
@@ -0,0 +1,106 @@
+package set
+
+import (
+	"github.com/dynago/dg/internal/jsonvalue"
+	// Arrays nested in a set are decoded as tuples.
+	_ "github.com/dynago/dg/tuple"
+)
+
+/* MarshalJSON encodes the set as a JSON array. Elements are encoded in insertion order. */
+func (s *Set) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+/* UnmarshalJSON decodes a JSON array into the set. Nested arrays become tuples and nested objects become dicts. */
+func (s *Set) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, true)
+	if err != nil {
+		return err
+	}
+	return fill(s, values)
+}
+
+/* MarshalJSON encodes the frozen set as a JSON array. Elements are encoded in insertion order. */
+func (f *FrozenSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(f)
+}
+
+/* UnmarshalJSON decodes a JSON array into the frozen set. Nested arrays become tuples and nested objects become dicts. */
+func (f *FrozenSet) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, true)
+	if err != nil {
+		return err
+	}
+	s := new(Set)
+	if err = fill(s, values); err != nil {
+		return err
+	}
+	f.set = s
+	return nil
+}
+
+/* MarshalJSON encodes a snapshot of the set as a JSON array. Elements are encoded in insertion order. */
+func (s *SyncSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+/* UnmarshalJSON decodes a JSON array into the set. Nested arrays become tuples and nested objects become dicts. */
+func (s *SyncSet) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, true)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set == nil {
+		s.set = new(Set)
+	}
+	return fill(s.set, values)
+}
+
+/* MarshalJSON encodes the sorted set as a JSON array. Elements are encoded in sorted order. */
+func (s *SortedSet) MarshalJSON() ([]byte, error) {
+	return marshalJSON(s)
+}
+
+/* UnmarshalJSON decodes a JSON array into the sorted set. Nested arrays become tuples and nested objects become dicts. */
+func (s *SortedSet) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, true)
+	if err != nil {
+		return err
+	}
+	return fill(s, values)
+}
+
+/* marshalJSON encodes the elements of the set as a JSON array in iteration order. */
+func marshalJSON(s SetInterface) ([]byte, error) {
+	values := make([]interface{}, 0, s.Length())
+	s.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return jsonvalue.EncodeArray(values, false)
+}
+
+/* fill replaces the elements of the set with the values. */
+func fill(s SetInterface, values []interface{}) error {
+	s.Init()
+	for _, value := range values {
+		if err := s.Add(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
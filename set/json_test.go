@@ -0,0 +1,45 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dynago/dg/tuple"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	s, _ := MakeSetFromValues("b", 3, "a", 1)
+	if b, err := json.Marshal(s); err != nil {
+		t.Error(err)
+	} else if string(b) != `["b",3,"a",1]` {
+		t.Errorf("Expected array in insertion order, got %s", b)
+	}
+	f, _ := MakeFrozenSetFromValues(2, 1)
+	if b, err := json.Marshal(f); err != nil || string(b) != `[2,1]` {
+		t.Errorf("Expected array in insertion order, got %s", b)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	s := new(Set)
+	if err := json.Unmarshal([]byte(`[1, [2, 3], 1]`), s); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tup, _ := tuple.MakeTupleFromValues(2, 3)
+	expected, _ := MakeSetFromValues(1, tup)
+	if equals, err := s.Equals(expected); err != nil || !equals {
+		t.Errorf("Expected %v, got %v", expected, s)
+	}
+	f := new(FrozenSet)
+	if err := json.Unmarshal([]byte(`[1, [2, 3]]`), f); err != nil {
+		t.Error(err)
+	} else if equals, _ := f.Equals(expected); !equals {
+		t.Errorf("Expected %v, got %v", expected, f)
+	}
+	ss := new(SyncSet)
+	if err := json.Unmarshal([]byte(`[1, [2, 3]]`), ss); err != nil {
+		t.Error(err)
+	} else if b, err := json.Marshal(ss); err != nil || string(b) != `[1,[2,3]]` {
+		t.Errorf("Expected sorted array, got %s", b)
+	}
+}
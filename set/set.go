@@ -1,88 +1,144 @@
-// Package set implements sets, which are collections which are both unordered and unindexed.
+// Package set implements sets, which are unindexed collections of unique elements kept in insertion order.
 package set
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
-	"github.com/dynago/dg/internal/helpers"
-	"github.com/dynago/dg/internal/iterable"
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
+	"github.com/dynago/dg/iterable"
 )
 
-// Set is a dynamic set structure.
+// element is a value stored in a set.
+type element struct {
+	value   interface{}
+	prev    *element // element added before this one
+	next    *element // element added after this one
+	removed bool     // set once the element is removed, so ForEach skips it
+}
+
+// Set is a dynamic set structure which keeps its elements in insertion order.
 type Set struct {
-	values map[string]interface{}
+	hasher  hasher.Hasher         // hashes values
+	buckets map[string][]*element // hash of value to elements with that hash
+	first   *element              // least recently added element
+	last    *element              // most recently added element
+	length  int
 }
 
 /* Get returns the value given a string representation of the bytes. */
 func (s *Set) Get(hash string) interface{} {
-	value, ok := s.values[hash]
+	bucket, ok := s.buckets[hash]
 	if !ok {
 		return nil
 	}
-	return value
+	return bucket[0].value
 }
 
 /* Length returns the number of elements in set. */
 func (s *Set) Length() int {
-	return len(s.values)
+	return s.length
 }
 
-/* Iterate returns the next key in set. */
+/* Iterate returns the next key in set. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
 func (s *Set) Iterate() <-chan interface{} {
-	c := make(chan interface{})
-	go func() {
-		for _, v := range s.values {
-			c <- v
+	return iterable.Channel(s)
+}
+
+/* ForEach calls the function on each element in set, in insertion order, until it returns false. Elements removed by the function are not visited. */
+func (s *Set) ForEach(fn func(interface{}) bool) {
+	for e := s.first; e != nil; {
+		if !fn(e.value) {
+			return
+		}
+		e = e.next
+		for e != nil && e.removed {
+			e = e.next
 		}
-		close(c)
-	}()
-	return c
+	}
 }
 
-/* Add adds element to the set. */
+/* Iterator returns an Iterator over a snapshot of the elements in set, in insertion order. */
+func (s *Set) Iterator() iterable.Iterator {
+	values := make([]interface{}, 0, s.length)
+	for e := s.first; e != nil; e = e.next {
+		values = append(values, e.value)
+	}
+	return iterable.FromSlice(values)
+}
+
+/* Add adds element to the end of the set. Adding an element already in the set does not change its place. */
 func (s *Set) Add(value interface{}) error {
-	hash, err := helpers.GetSHA(value)
-	if err != nil {
+	hash, i, err := s.lookup(value)
+	if err != nil || i >= 0 {
 		return err
 	}
-	s.values[hash] = value
+	e := &element{value: value}
+	s.buckets[hash] = append(s.buckets[hash], e)
+	s.link(e)
+	s.length += 1
 	return nil
 }
 
 /* Remove removes element from the set. */
 func (s *Set) Remove(value interface{}) error {
-	hash, err := helpers.GetSHA(value)
-	if err != nil {
+	hash, i, err := s.lookup(value)
+	if err != nil || i < 0 {
 		return err
 	}
-	delete(s.values, hash)
+	bucket := s.buckets[hash]
+	s.unlink(bucket[i])
+	bucket[i].removed = true
+	if len(bucket) == 1 {
+		delete(s.buckets, hash)
+	} else {
+		s.buckets[hash] = append(bucket[:i:i], bucket[i+1:]...)
+	}
+	s.length -= 1
 	return nil
 }
 
 /* Combine updates the set, adding elements from the other set. */
 func (s *Set) Combine(other SetInterface) error {
-	for value := range other.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return err
-		}
-		s.values[hash] = value
-	}
-	return nil
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		err = s.Add(value)
+		return err == nil
+	})
+	return err
 }
 
-/* Pop pops and return an arbitrary element from the set. */
+/* Pop pops and return the most recently added element from the set. */
 func (s *Set) Pop() (interface{}, error) {
-	for value := range s.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return nil, err
-		}
-		delete(s.values, hash)
-		return value, nil
+	return s.PopLast()
+}
+
+/* PopFirst pops and returns the least recently added element from the set. */
+func (s *Set) PopFirst() (interface{}, error) {
+	return s.popElement(s.first)
+}
+
+/* PopLast pops and returns the most recently added element from the set. */
+func (s *Set) PopLast() (interface{}, error) {
+	return s.popElement(s.last)
+}
+
+/* MoveToEnd moves the element to the end of the set, as if it had just been added. */
+func (s *Set) MoveToEnd(value interface{}) error {
+	hash, i, err := s.lookup(value)
+	if err != nil {
+		return err
+	}
+	if i < 0 {
+		return &errs.KeyError{Key: value, Structure: "set"}
 	}
-	return nil, nil
+	e := s.buckets[hash][i]
+	s.unlink(e)
+	s.link(e)
+	return nil
 }
 
 /* Clear clears all elements from the set. */
@@ -93,26 +149,26 @@ func (s *Set) Clear() error {
 
 /* Contains tests for membership in the set. */
 func (s *Set) Contains(value interface{}) (bool, error) {
-	hash, err := helpers.GetSHA(value)
-	if err != nil {
-		return false, err
-	}
-	_, ok := s.values[hash]
-	return ok, nil
+	_, i, err := s.lookup(value)
+	return i >= 0, err
 }
 
 /* Disjoint returns true if the set has no elements in common with the other set. */
 func (s *Set) Disjoint(other SetInterface) (bool, error) {
-	for value := range other.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return false, err
-		}
-		if _, ok := s.values[hash]; ok {
-			return false, nil
+	disjoint := true
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		var ok bool
+		if ok, err = s.Contains(value); err != nil {
+			return false
 		}
+		disjoint = !ok
+		return disjoint
+	})
+	if err != nil {
+		return false, err
 	}
-	return true, nil
+	return disjoint, nil
 }
 
 /* Equals returns true if the set has all elements in common with the other set. */
@@ -128,18 +184,35 @@ func (s *Set) Equals(other SetInterface) (bool, error) {
 	return ok1 && ok2, nil
 }
 
+/* HashKey returns the bytes which identify the set, so it can be used as a dict key or set element. The order of the elements does not change the key. The set must not be changed while it is a key. */
+func (s *Set) HashKey() ([]byte, error) {
+	return hashKey(s)
+}
+
+/* EqualTo returns true if the other value is a set with the same elements. */
+func (s *Set) EqualTo(other interface{}) bool {
+	o, ok := other.(SetInterface)
+	if !ok {
+		return false
+	}
+	equals, err := s.Equals(o)
+	return err == nil && equals
+}
+
 /* SupersetOf tests whether every element in the other set is in the set. */
 func (s *Set) SupersetOf(other SetInterface) (bool, error) {
-	for value := range other.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return false, err
-		}
-		if _, ok := s.values[hash]; !ok {
-			return false, nil
+	superset := true
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		if superset, err = s.Contains(value); err != nil {
+			return false
 		}
+		return superset
+	})
+	if err != nil {
+		return false, err
 	}
-	return true, nil
+	return superset, nil
 }
 
 /* SubsetOf tests whether every element in the set is in the other set. */
@@ -150,20 +223,23 @@ func (s *Set) SubsetOf(other SetInterface) (bool, error) {
 
 /* Intersection returns a new set with elements common to the set and all others. */
 func (s *Set) Intersection(other SetInterface) (SetInterface, error) {
-	output, err := MakeSet()
+	output, err := MakeSetWithHasher(s.hasher)
 	if err != nil {
 		return nil, err
 	}
-	for value := range other.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return nil, err
+	other.ForEach(func(value interface{}) bool {
+		var hash string
+		var i int
+		if hash, i, err = s.lookup(value); err != nil {
+			return false
 		}
-		if v, ok := s.values[hash]; ok {
-			if err = output.Add(v); err != nil {
-				return nil, err
-			}
+		if i >= 0 {
+			err = output.Add(s.buckets[hash][i].value)
 		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return output, nil
 }
@@ -191,17 +267,19 @@ func (s *Set) Difference(other SetInterface) (SetInterface, error) {
 	if err != nil {
 		return nil, err
 	}
-	for value := range other.Iterate() {
-		hash, err := helpers.GetSHA(value)
-		if err != nil {
-			return nil, err
+	other.ForEach(func(value interface{}) bool {
+		var hash string
+		var i int
+		if hash, i, err = s.lookup(value); err != nil {
+			return false
 		}
-		if v, ok := s.values[hash]; ok {
-			err := output.Remove(v)
-			if err != nil {
-				return nil, err
-			}
+		if i >= 0 {
+			err = output.Remove(s.buckets[hash][i].value)
 		}
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return output, nil
 }
@@ -218,35 +296,106 @@ func (s *Set) Union(other SetInterface) (SetInterface, error) {
 
 /* Copy creates a copy of the current SetInterface */
 func (s *Set) Copy() (SetInterface, error) {
-	output, err := MakeSet(s)
+	output, err := MakeSetWithHasher(s.hasher, s)
 	return output, err
 }
 
-/* String returns a string representation of the set. */
+/* String returns a string representation of the set, in insertion order. */
 func (s *Set) String() string {
 	output := "("
-	for _, value := range s.values {
-		output += fmt.Sprintf("%v ", value)
+	for e := s.first; e != nil; e = e.next {
+		output += fmt.Sprintf("%v ", e.value)
 	}
 	output = strings.Trim(output, " ") + ")"
 	return output
 }
 
+/* Hasher returns the Hasher used to hash values in the set. */
+func (s *Set) Hasher() hasher.Hasher {
+	return s.hasher
+}
+
+/* lookup returns the hash of the value and the index of the value in its bucket. The index is -1 if the value is not in the set. */
+func (s *Set) lookup(value interface{}) (string, int, error) {
+	hash, err := hasher.Hash(s.hasher, value)
+	if err != nil {
+		return "", -1, err
+	}
+	for i, e := range s.buckets[hash] {
+		if equality.Equal(value, e.value) {
+			return hash, i, nil
+		}
+	}
+	return hash, -1, nil
+}
+
+/* link adds the element to the end of the insertion order. */
+func (s *Set) link(e *element) {
+	e.prev, e.next = s.last, nil
+	if s.last == nil {
+		s.first = e
+	} else {
+		s.last.next = e
+	}
+	s.last = e
+}
+
+/* unlink removes the element from the insertion order. The element keeps its next pointer, so ForEach can continue from it. */
+func (s *Set) unlink(e *element) {
+	if e.prev == nil {
+		s.first = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next == nil {
+		s.last = e.prev
+	} else {
+		e.next.prev = e.prev
+	}
+}
+
+/* popElement removes the element from the set and returns its value, or an EmptyError if the element is nil because the set is empty. */
+func (s *Set) popElement(e *element) (interface{}, error) {
+	if e == nil {
+		return nil, &errs.EmptyError{Op: "pop from", Structure: "set"}
+	}
+	if err := s.Remove(e.value); err != nil {
+		return nil, err
+	}
+	return e.value, nil
+}
+
 /* Init initializes the set. */
 func (s *Set) Init() {
-	s.values = make(map[string]interface{})
+	if s.hasher == nil {
+		s.hasher = hasher.Default()
+	}
+	for e := s.first; e != nil; e = e.next {
+		e.removed = true
+	}
+	s.buckets = make(map[string][]*element)
+	s.first, s.last = nil, nil
+	s.length = 0
 }
 
 /* MakeSet initializes a new set object using an Iterable. */
 func MakeSet(it ...iterable.Iterable) (SetInterface, error) {
-	output := new(Set)
+	output, err := MakeSetWithHasher(hasher.Default(), it...)
+	return output, err
+}
+
+/* MakeSetWithHasher initializes a new set object which hashes values using the given Hasher. */
+func MakeSetWithHasher(h hasher.Hasher, it ...iterable.Iterable) (SetInterface, error) {
+	output := &Set{hasher: h}
 	output.Init()
 	if len(it) > 0 {
-		i := it[0]
-		for val := range i.Iterate() {
-			if err := output.Add(val); err != nil {
-				return nil, err
-			}
+		var err error
+		iterable.ForEach(it[0], func(val interface{}) bool {
+			err = output.Add(val)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
 	return output, nil
@@ -263,3 +412,28 @@ func MakeSetFromValues(values ...interface{}) (SetInterface, error) {
 	}
 	return output, nil
 }
+
+/* hashKey returns the bytes which identify the elements of the set, made from their sorted hashes so that the order of the elements does not change the key. */
+func hashKey(s SetInterface) ([]byte, error) {
+	h := hasher.Default()
+	hashes := make([]string, 0, s.Length())
+	var err error
+	s.ForEach(func(value interface{}) bool {
+		var hash string
+		if hash, err = hasher.Hash(h, value); err != nil {
+			return false
+		}
+		hashes = append(hashes, hash)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(hashes)
+	output := make([]byte, 0)
+	for _, hash := range hashes {
+		output = append(output, hash...)
+		output = append(output, ',')
+	}
+	return output, nil
+}
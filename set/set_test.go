@@ -1,40 +1,17 @@
 package set
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
 	"testing"
-)
-
-/* permutation code from https://yourbasic.org/golang/generate-permutation-slice-string/ */
 
-// Perm calls f with each permutation of a.
-func perm(a []string, f func([]string)) {
-	permHelper(a, f, 0)
-}
-
-// Permute the values at index i to len(a)-1.
-func permHelper(a []string, f func([]string), i int) {
-	if i > len(a) {
-		f(a)
-		return
-	}
-	permHelper(a, f, i+1)
-	for j := i + 1; j < len(a); j++ {
-		a[i], a[j] = a[j], a[i]
-		permHelper(a, f, i+1)
-		a[i], a[j] = a[j], a[i]
-	}
-}
+	"github.com/dynago/dg/errs"
+)
 
 func printChecker(str string, values []string) bool {
-	output := false
-	perm(values, func(a []string) {
-		potentialMatch := fmt.Sprint(a)
-		if potentialMatch[1:len(potentialMatch)-1] == str[1:len(str)-1] {
-			output = true
-		}
-	})
-	return output
+	expected := fmt.Sprint(values)
+	return expected[1:len(expected)-1] == str[1:len(str)-1]
 }
 
 func TestAdd(t *testing.T) {
@@ -94,7 +71,7 @@ func TestRemove(t *testing.T) {
 
 	vals := []string{"1", "hello"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -115,7 +92,7 @@ func TestCombine(t *testing.T) {
 
 	vals := []string{"1", "2.2", "hello"}
 	if ok := printChecker(s1.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s1.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s1.String(), vals)
 	}
 }
 
@@ -125,28 +102,72 @@ func TestPop(t *testing.T) {
 		t.Error(err)
 	}
 
-	value, err := s.Pop()
+	if value, err := s.Pop(); err != nil {
+		t.Error(err)
+	} else if value != "hello" {
+		t.Fatalf("Pop popped an unexpected value: %v", value)
+	}
+
+	if ok := printChecker(s.String(), []string{"1", "2.2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+}
+
+func TestOrder(t *testing.T) {
+	s, err := MakeSetFromValues(3, 1, 2, 1)
 	if err != nil {
 		t.Error(err)
 	}
+	o := s.(*Set)
 
-	var vals []string
-	switch value {
-	case 1:
-		vals = append(vals, "2.2")
-		vals = append(vals, "hello")
-	case 2.2:
-		vals = append(vals, "1")
-		vals = append(vals, "hello")
-	case "hello":
-		vals = append(vals, "1")
-		vals = append(vals, "2.2")
-	default:
-		t.Errorf("Pop popped an unexpected value: %s", value)
+	if ok := printChecker(o.String(), []string{"3", "1", "2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", o.String())
 	}
 
-	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of 2 of %v", s.String(), vals)
+	if err := o.MoveToEnd(3); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(o.String(), []string{"1", "2", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", o.String())
+	}
+
+	if err := o.MoveToEnd(4); !errors.Is(err, errs.ErrKeyNotFound) {
+		t.Fatalf("Expected missing element error")
+	}
+
+	if value, err := o.PopFirst(); err != nil {
+		t.Error(err)
+	} else if value != 1 {
+		t.Fatalf("Got %v, was expecting 1", value)
+	}
+
+	if value, err := o.PopLast(); err != nil {
+		t.Error(err)
+	} else if value != 3 {
+		t.Fatalf("Got %v, was expecting 3", value)
+	}
+
+	if err := o.Add(0); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(o.String(), []string{"2", "0"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", o.String())
+	}
+
+	o.Remove(2)
+	o.Remove(0)
+	var emptyErr *errs.EmptyError
+	if value, err := o.PopFirst(); !errors.As(err, &emptyErr) || value != nil {
+		t.Fatalf("Got %v and %v, was expecting an EmptyError from an empty set", value, err)
+	} else if emptyErr.Structure != "set" {
+		t.Fatalf("Got %+v, was expecting a set", emptyErr)
+	}
+
+	if value, err := Synchronized(s).(OrderedSetInterface).PopLast(); !errors.Is(err, errs.ErrEmpty) || value != nil {
+		t.Fatalf("Got %v and %v, was expecting ErrEmpty from an empty set", value, err)
+	}
+
+	f, _ := MakeFrozenSetFromValues(1)
+	if _, err := Synchronized(f).(OrderedSetInterface).PopFirst(); err == nil {
+		t.Fatalf("Expected insertion order error")
 	}
 }
 
@@ -165,7 +186,7 @@ func TestClear(t *testing.T) {
 
 	vals := []string{}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	// Check that we can still add
@@ -353,7 +374,7 @@ func TestIntersection(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	s, err = s2.Intersection(s1)
@@ -361,7 +382,7 @@ func TestIntersection(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -385,7 +406,7 @@ func TestDifference(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), diff12); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), diff12)
+		t.Fatalf("Got %s, was expecting %v", s.String(), diff12)
 	}
 
 	s, err = s2.Difference(s1)
@@ -393,7 +414,7 @@ func TestDifference(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), diff21); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), diff21)
+		t.Fatalf("Got %s, was expecting %v", s.String(), diff21)
 	}
 
 	s, err = s1.SymmetricDifference(s2)
@@ -401,15 +422,16 @@ func TestDifference(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), symm); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), symm)
+		t.Fatalf("Got %s, was expecting %v", s.String(), symm)
 	}
 
+	symm = []string{"hello", "2.2"}
 	s, err = s2.SymmetricDifference(s1)
 	if err != nil {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), symm); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), symm)
+		t.Fatalf("Got %s, was expecting %v", s.String(), symm)
 	}
 }
 
@@ -436,7 +458,7 @@ func TestUnion(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 
 	s, err = s.Union(s3)
@@ -444,7 +466,7 @@ func TestUnion(t *testing.T) {
 		t.Error(err)
 	}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
 	}
 }
 
@@ -461,6 +483,242 @@ func TestIterable(t *testing.T) {
 
 	vals := []string{"1", "2.2"}
 	if ok := printChecker(s.String(), vals); !ok {
-		t.Fatalf("Got %s, expected some sort of permutation of %v", s.String(), vals)
+		t.Fatalf("Got %s, was expecting %v", s.String(), vals)
+	}
+}
+
+type countingHasher struct {
+	calls int
+}
+
+func (h *countingHasher) Hash(value interface{}) (string, error) {
+	h.calls++
+	return fmt.Sprintf("%T:%v", value, value), nil
+}
+
+func TestMakeSetWithHasher(t *testing.T) {
+	h := new(countingHasher)
+	s1, err := MakeSetWithHasher(h)
+	if err != nil {
+		t.Error(err)
+	}
+	if err := s1.Add(1); err != nil {
+		t.Error(err)
+	}
+	if err := s1.Add(2); err != nil {
+		t.Error(err)
+	}
+	if h.calls != 2 {
+		t.Fatalf("Expected the hasher to be called 2 times, got %d", h.calls)
+	}
+
+	s2, err := MakeSetFromValues(2, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if s, err := s1.Intersection(s2); err != nil {
+		t.Error(err)
+	} else if s.(*Set).Hasher() != h {
+		t.Fatal("Intersection did not keep the hasher of the set")
+	}
+	if s, err := s1.Union(s2); err != nil {
+		t.Error(err)
+	} else if s.(*Set).Hasher() != h {
+		t.Fatal("Union did not keep the hasher of the set")
+	}
+	if s, err := s1.Difference(s2); err != nil {
+		t.Error(err)
+	} else if s.(*Set).Hasher() != h {
+		t.Fatal("Difference did not keep the hasher of the set")
+	}
+}
+
+type hashableTester struct {
+	id   int
+	name string
+}
+
+func (h hashableTester) HashKey() ([]byte, error) {
+	return []byte(fmt.Sprint(h.id)), nil
+}
+
+func (h hashableTester) EqualTo(other interface{}) bool {
+	o, ok := other.(hashableTester)
+	return ok && o.id == h.id
+}
+
+func TestHashable(t *testing.T) {
+	s, err := MakeSetFromValues(hashableTester{1, "a"}, hashableTester{2, "a"}, hashableTester{1, "b"})
+	if err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 2 {
+		t.Fatalf("Expected the length of the set to be 2, got %d", s.Length())
+	}
+
+	if contains, err := s.Contains(hashableTester{2, "c"}); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The set does not contain {2 c} when it should")
+	}
+
+	if err := s.Remove(hashableTester{1, "c"}); err != nil {
+		t.Error(err)
+	}
+	if s.Length() != 1 {
+		t.Fatalf("Expected the length of the set to be 1, got %d", s.Length())
+	}
+}
+
+type collidingHasher struct{}
+
+func (collidingHasher) Hash(value interface{}) (string, error) {
+	return "collision", nil
+}
+
+func TestCollidingHasher(t *testing.T) {
+	s1, err := MakeSetWithHasher(collidingHasher{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	values := []interface{}{1, 1.0, "1", 2, 1}
+	for _, value := range values {
+		if err := s1.Add(value); err != nil {
+			t.Error(err)
+		}
+	}
+	if s1.Length() != 4 {
+		t.Fatalf("Expected the length of the set to be 4, got %d", s1.Length())
+	}
+	if ok := printChecker(s1.String(), []string{"1", "1", "1", "2"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s1.String())
+	}
+
+	if err := s1.Remove(1.0); err != nil {
+		t.Error(err)
+	}
+	if contains, err := s1.Contains(1.0); err != nil {
+		t.Error(err)
+	} else if contains == true {
+		t.Fatal("The set contains 1.0 when it shouldn't")
+	}
+	if contains, err := s1.Contains(1); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The set does not contain 1 when it should")
+	}
+
+	s2, err := MakeSetFromValues(2, "1", 3)
+	if err != nil {
+		t.Error(err)
+	}
+	if s, err := s1.Intersection(s2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"2", "1"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+	if s, err := s1.Difference(s2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+}
+
+func TestPopDoesNotLeak(t *testing.T) {
+	values := make([]interface{}, 100)
+	for i := range values {
+		values[i] = i
+	}
+	s, err := MakeSetFromValues(values...)
+	if err != nil {
+		t.Error(err)
+	}
+
+	before := runtime.NumGoroutine()
+	for s.Length() > 0 {
+		if _, err := s.Pop(); err != nil {
+			t.Error(err)
+		}
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("Expected no more than %d goroutines, got %d", before, after)
+	}
+}
+
+func TestForEachRemove(t *testing.T) {
+	s, _ := MakeSetFromValues(1, 2, 3, 4)
+	values := make([]string, 0)
+	s.ForEach(func(value interface{}) bool {
+		values = append(values, fmt.Sprint(value))
+		if value == 1 {
+			s.Remove(1)
+			s.Remove(2)
+		}
+		return true
+	})
+	if fmt.Sprint(values) != "[1 3 4]" {
+		t.Fatalf("Got %v, was expecting removed elements not to be visited", values)
+	}
+
+	values = values[:0]
+	s.ForEach(func(value interface{}) bool {
+		values = append(values, fmt.Sprint(value))
+		s.Clear()
+		return true
+	})
+	if fmt.Sprint(values) != "[3]" {
+		t.Fatalf("Got %v, was expecting no elements after clearing", values)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s, err := MakeSetFromValues(1, 2, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	count := 0
+	s.ForEach(func(value interface{}) bool {
+		count += 1
+		return false
+	})
+	if count != 1 {
+		t.Fatalf("Expected ForEach to stop after 1 element, got %d", count)
+	}
+
+	iter := s.Iterator()
+	defer iter.Close()
+	vals := make([]string, 0)
+	for iter.Next() {
+		vals = append(vals, fmt.Sprint(iter.Value()))
+	}
+	if ok := printChecker(fmt.Sprint(vals), []string{"1", "2", "3"}); !ok {
+		t.Fatalf("Got %v, which was unexpected", vals)
+	}
+}
+
+func TestSetHashKey(t *testing.T) {
+	s1, _ := MakeSetFromValues(1, 2.2, "hello")
+	s2, _ := MakeSetFromValues("hello", 1, 2.2)
+	outer, _ := MakeSetFromValues(s1)
+	if contains, err := outer.Contains(s2); err != nil {
+		t.Error(err)
+	} else if !contains {
+		t.Fatal("Expected a set with the same elements in another order to be found")
+	}
+
+	sorted, _ := MakeSortedSetFromValues(2.2, "hello", 1)
+	other, _ := MakeSetFromValues(sorted, Synchronized(s1))
+	if contains, err := other.Contains(Synchronized(s2)); err != nil || !contains {
+		t.Fatal("Expected an equal synchronized set to be found")
+	} else if contains, err := other.Contains(sorted); err != nil || !contains {
+		t.Fatal("Expected the sorted set to be found")
+	}
+
+	s3, _ := MakeSetFromValues(1, 2.2)
+	if contains, _ := outer.Contains(s3); contains {
+		t.Fatal("Expected a set with other elements not to be found")
 	}
 }
@@ -0,0 +1,424 @@
+package set
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
+	"github.com/dynago/dg/internal/helpers"
+	"github.com/dynago/dg/internal/tree"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/order"
+)
+
+// SortedSet is a set which keeps its elements sorted by a comparison function, order.Compare by default. Elements which compare equal are the same element, so 1 and 1.0 are the same element in a sorted set. Lookups, updates and rank queries take O(log n) time.
+type SortedSet struct {
+	compare func(a interface{}, b interface{}) (int, error)
+	tree    *tree.Tree
+}
+
+/* Get returns the value given a string representation of the bytes, hashed by the default Hasher. It takes O(n) time. */
+func (s *SortedSet) Get(hash string) interface{} {
+	var found interface{}
+	s.tree.Ascend(func(value interface{}, _ interface{}) bool {
+		if h, err := hasher.Hash(hasher.Default(), value); err == nil && h == hash {
+			found = value
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+/* Length returns the number of elements in set. */
+func (s *SortedSet) Length() int {
+	return s.tree.Len()
+}
+
+/* Iterate returns the next key in set, in sorted order. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (s *SortedSet) Iterate() <-chan interface{} {
+	return iterable.Channel(s)
+}
+
+/* ForEach calls the function on each element in set, in sorted order, until it returns false. The function must not add or remove elements. */
+func (s *SortedSet) ForEach(fn func(interface{}) bool) {
+	s.tree.Ascend(func(value interface{}, _ interface{}) bool {
+		return fn(value)
+	})
+}
+
+/* Iterator returns an Iterator over a snapshot of the elements in set, in sorted order. */
+func (s *SortedSet) Iterator() iterable.Iterator {
+	return iterable.FromSlice(s.values())
+}
+
+/* Add adds element to the set. Adding an element equal to one already in the set keeps the element already there. It returns an error if the element cannot be compared with the elements in the set. */
+func (s *SortedSet) Add(value interface{}) error {
+	_, err := s.tree.Put(value, nil)
+	return err
+}
+
+/* Remove removes element from the set. */
+func (s *SortedSet) Remove(value interface{}) error {
+	_, _, _, err := s.tree.Delete(value)
+	return err
+}
+
+/* Combine updates the set, adding elements from the other set. */
+func (s *SortedSet) Combine(other SetInterface) error {
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		err = s.Add(value)
+		return err == nil
+	})
+	return err
+}
+
+/* Pop pops and returns the largest element from the set, or an *errs.EmptyError if it is empty. */
+func (s *SortedSet) Pop() (interface{}, error) {
+	value, _, ok := s.tree.Max()
+	if !ok {
+		return nil, &errs.EmptyError{Op: "pop from", Structure: "set"}
+	}
+	value, _, _, err := s.tree.Delete(value)
+	return value, err
+}
+
+/* Clear clears all elements from the set. */
+func (s *SortedSet) Clear() error {
+	s.tree.Clear()
+	return nil
+}
+
+/* Contains tests for membership in the set. */
+func (s *SortedSet) Contains(value interface{}) (bool, error) {
+	_, ok, err := s.tree.Get(value)
+	return ok, err
+}
+
+/* Disjoint returns true if the set has no elements in common with the other set. */
+func (s *SortedSet) Disjoint(other SetInterface) (bool, error) {
+	disjoint := true
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		var ok bool
+		if ok, err = s.Contains(value); err != nil {
+			return false
+		}
+		disjoint = !ok
+		return disjoint
+	})
+	if err != nil {
+		return false, err
+	}
+	return disjoint, nil
+}
+
+/* Equals returns true if the set has all elements in common with the other set. */
+func (s *SortedSet) Equals(other SetInterface) (bool, error) {
+	var ok1, ok2 bool
+	var err error
+	if ok1, err = other.SupersetOf(s); err != nil {
+		return false, err
+	}
+	if ok2, err = s.SupersetOf(other); err != nil {
+		return false, err
+	}
+	return ok1 && ok2, nil
+}
+
+/* HashKey returns the bytes which identify the set, so it can be used as a dict key or set element. The set must not be changed while it is a key. */
+func (s *SortedSet) HashKey() ([]byte, error) {
+	return hashKey(s)
+}
+
+/* EqualTo returns true if the other value is a set with the same elements. */
+func (s *SortedSet) EqualTo(other interface{}) bool {
+	o, ok := other.(SetInterface)
+	if !ok {
+		return false
+	}
+	equals, err := s.Equals(o)
+	return err == nil && equals
+}
+
+/* SupersetOf tests whether every element in the other set is in the set. */
+func (s *SortedSet) SupersetOf(other SetInterface) (bool, error) {
+	superset := true
+	var err error
+	other.ForEach(func(value interface{}) bool {
+		if superset, err = s.Contains(value); err != nil {
+			return false
+		}
+		return superset
+	})
+	if err != nil {
+		return false, err
+	}
+	return superset, nil
+}
+
+/* SubsetOf tests whether every element in the set is in the other set. */
+func (s *SortedSet) SubsetOf(other SetInterface) (bool, error) {
+	ok, err := other.SupersetOf(s)
+	return ok, err
+}
+
+/* Intersection returns a new sorted set with elements common to the set and the other set. */
+func (s *SortedSet) Intersection(other SetInterface) (SetInterface, error) {
+	output, err := s.merge(other, false, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* SymmetricDifference returns a new sorted set with elements in either the set or the other but not both. */
+func (s *SortedSet) SymmetricDifference(other SetInterface) (SetInterface, error) {
+	output, err := s.merge(other, true, false, true)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* Difference returns a new sorted set with elements in the set that are not in the other set. */
+func (s *SortedSet) Difference(other SetInterface) (SetInterface, error) {
+	output, err := s.merge(other, true, false, false)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* Union returns a new sorted set with elements from the set and the other set. */
+func (s *SortedSet) Union(other SetInterface) (SetInterface, error) {
+	output, err := s.merge(other, true, true, true)
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+/* Copy creates a copy of the current SetInterface, ordered by the same comparison function. */
+func (s *SortedSet) Copy() (SetInterface, error) {
+	return s.build(s.values()), nil
+}
+
+/* String returns a string representation of the set, in sorted order. */
+func (s *SortedSet) String() string {
+	output := "("
+	s.tree.Ascend(func(value interface{}, _ interface{}) bool {
+		output += fmt.Sprintf("%v ", value)
+		return true
+	})
+	output = strings.Trim(output, " ") + ")"
+	return output
+}
+
+/* Min returns the smallest element in the set, and false if the set is empty. */
+func (s *SortedSet) Min() (interface{}, bool) {
+	value, _, ok := s.tree.Min()
+	return value, ok
+}
+
+/* Max returns the largest element in the set, and false if the set is empty. */
+func (s *SortedSet) Max() (interface{}, bool) {
+	value, _, ok := s.tree.Max()
+	return value, ok
+}
+
+/* Floor returns the largest element in the set which is less than or equal to the value, and false if there is none. */
+func (s *SortedSet) Floor(value interface{}) (interface{}, bool, error) {
+	return s.tree.Floor(value)
+}
+
+/* Ceiling returns the smallest element in the set which is greater than or equal to the value, and false if there is none. */
+func (s *SortedSet) Ceiling(value interface{}) (interface{}, bool, error) {
+	return s.tree.Ceiling(value)
+}
+
+/* Range returns a new sorted set of the elements in the set which are greater than or equal to lo and less than hi. */
+func (s *SortedSet) Range(lo interface{}, hi interface{}) (*SortedSet, error) {
+	values := make([]interface{}, 0)
+	var errc error
+	err := s.tree.AscendFrom(lo, func(value interface{}, _ interface{}) bool {
+		var c int
+		if c, errc = s.compare(value, hi); errc != nil || c >= 0 {
+			return false
+		}
+		values = append(values, value)
+		return true
+	})
+	if err == nil {
+		err = errc
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.build(values), nil
+}
+
+/* IndexOf returns the index of the element in sorted order, or -1 if it is not in the set. */
+func (s *SortedSet) IndexOf(value interface{}) (int, error) {
+	if ok, err := s.Contains(value); err != nil || !ok {
+		return -1, err
+	}
+	return s.tree.Rank(value)
+}
+
+/* At returns the element at index i in sorted order. Negative indexes count from the end of the set. */
+func (s *SortedSet) At(i int) (interface{}, error) {
+	j, ok := helpers.ElementIndex(i, s.tree.Len())
+	if !ok {
+		return nil, &errs.IndexError{Index: i, Length: s.tree.Len(), Structure: "set"}
+	}
+	value, _, _ := s.tree.Select(j)
+	return value, nil
+}
+
+/* values returns the elements in the set in sorted order. */
+func (s *SortedSet) values() []interface{} {
+	values := make([]interface{}, 0, s.Length())
+	s.tree.Ascend(func(value interface{}, _ interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+/* build returns a new set ordered by the same comparison function holding the values, which must be sorted with no two values equal. */
+func (s *SortedSet) build(values []interface{}) *SortedSet {
+	return &SortedSet{compare: s.compare, tree: tree.Build(s.compare, values, make([]interface{}, len(values)))}
+}
+
+/* sorted returns the elements of the other set sorted by the comparison function of the set, keeping the first of any which compare equal. Elements which are already in order, such as those of a sorted set with the same order, are not sorted again. */
+func (s *SortedSet) sorted(other SetInterface) ([]interface{}, error) {
+	values := make([]interface{}, 0, other.Length())
+	other.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	inOrder := true
+	for i := 1; i < len(values) && inOrder; i++ {
+		c, err := s.compare(values[i-1], values[i])
+		if err != nil {
+			return nil, err
+		}
+		inOrder = c < 0
+	}
+	if inOrder {
+		return values, nil
+	}
+	var err error
+	sort.SliceStable(values, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+		var c int
+		c, err = s.compare(values[i], values[j])
+		return c < 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	unique := values[:0]
+	for i, value := range values {
+		if i > 0 {
+			c, err := s.compare(unique[len(unique)-1], value)
+			if err != nil {
+				return nil, err
+			}
+			if c == 0 {
+				continue
+			}
+		}
+		unique = append(unique, value)
+	}
+	return unique, nil
+}
+
+/* merge walks the elements of the set and the other set in sorted order, and returns a new sorted set of the elements only in the set if left is true, in both sets if both is true, and only in the other set if right is true. Elements in both sets are taken from the set. */
+func (s *SortedSet) merge(other SetInterface, left bool, both bool, right bool) (*SortedSet, error) {
+	a := s.values()
+	b, err := s.sorted(other)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		c, err := s.compare(a[i], b[j])
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case c < 0:
+			if left {
+				values = append(values, a[i])
+			}
+			i++
+		case c > 0:
+			if right {
+				values = append(values, b[j])
+			}
+			j++
+		default:
+			if both {
+				values = append(values, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	if left {
+		values = append(values, a[i:]...)
+	}
+	if right {
+		values = append(values, b[j:]...)
+	}
+	return s.build(values), nil
+}
+
+/* Init initializes the set. */
+func (s *SortedSet) Init() {
+	if s.compare == nil {
+		s.compare = order.Compare
+	}
+	s.tree = tree.New(s.compare)
+}
+
+/* MakeSortedSet initializes a new set ordered by order.Compare using an Iterable. */
+func MakeSortedSet(it ...iterable.Iterable) (*SortedSet, error) {
+	return MakeSortedSetWithCompare(order.Compare, it...)
+}
+
+/* MakeSortedSetWithCompare initializes a new set ordered by the comparison function, which returns -1, 0 or 1 as a is less than, equal to or greater than b. */
+func MakeSortedSetWithCompare(compare func(a interface{}, b interface{}) (int, error), it ...iterable.Iterable) (*SortedSet, error) {
+	output := &SortedSet{compare: compare}
+	output.Init()
+	if len(it) > 0 {
+		var err error
+		iterable.ForEach(it[0], func(val interface{}) bool {
+			err = output.Add(val)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+/* MakeSortedSetFromValues initializes a new set ordered by order.Compare using interface{} objects. */
+func MakeSortedSetFromValues(values ...interface{}) (*SortedSet, error) {
+	output, _ := MakeSortedSet()
+	for _, val := range values {
+		if err := output.Add(val); err != nil {
+			return nil, err
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,246 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dynago/dg/codec"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
+	"github.com/dynago/dg/tuple"
+)
+
+func TestSortedSet(t *testing.T) {
+	s, err := MakeSortedSetFromValues("c", 3, "a", 1.5, "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.String() != "(1.5 3 a b c)" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if err := s.Add(3.0); err != nil {
+		t.Error(err)
+	} else if s.Length() != 5 {
+		t.Fatalf("Expected 3.0 to be the same element as 3, got %v", s)
+	}
+
+	if err := s.Add([]int{1}); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+
+	if ok, err := s.Contains(1.5); err != nil || !ok {
+		t.Fatal("Expected 1.5 to be in the set")
+	}
+
+	if value, err := s.Pop(); err != nil || value != "c" {
+		t.Fatalf("Got %v, was expecting c", value)
+	}
+
+	if err := s.Remove("a"); err != nil {
+		t.Error(err)
+	} else if s.String() != "(1.5 3 b)" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	hash, _ := hasher.Hash(hasher.Default(), "b")
+	if value := s.Get(hash); value != "b" {
+		t.Fatalf("Got %v, was expecting b", value)
+	}
+
+	other, _ := MakeSetFromValues("b", 3, 1.5)
+	if ok, err := s.Equals(other); err != nil || !ok {
+		t.Fatal("Expected sets with the same elements to be equal")
+	}
+
+	c, err := s.Copy()
+	if err != nil {
+		t.Error(err)
+	} else if err := c.Add("d"); err != nil {
+		t.Error(err)
+	} else if s.Length() != 3 || c.String() != "(1.5 3 b d)" {
+		t.Fatalf("Got %v and %v, which was unexpected", s, c)
+	}
+
+	if err := s.Clear(); err != nil || s.Length() != 0 {
+		t.Fatal("Expected set to be empty")
+	} else if value, err := s.Pop(); !errors.Is(err, errs.ErrEmpty) || value != nil {
+		t.Fatalf("Got %v and %v, was expecting ErrEmpty", value, err)
+	}
+}
+
+func TestSortedSetOperations(t *testing.T) {
+	s1, _ := MakeSortedSetFromValues(1, 2, 3, 4, 5)
+	s2, _ := MakeSortedSetFromValues(4, 5.0, 6, 7)
+	s3, _ := MakeSetFromValues(7, 5, 3, 3.0, 1)
+
+	tests := []struct {
+		name     string
+		fn       func(SetInterface) (SetInterface, error)
+		other    SetInterface
+		expected string
+	}{
+		{"Union", s1.Union, s2, "(1 2 3 4 5 6 7)"},
+		{"Intersection", s1.Intersection, s2, "(4 5)"},
+		{"Difference", s1.Difference, s2, "(1 2 3)"},
+		{"SymmetricDifference", s1.SymmetricDifference, s2, "(1 2 3 6 7)"},
+		{"Union", s1.Union, s3, "(1 2 3 4 5 7)"},
+		{"Intersection", s1.Intersection, s3, "(1 3 5)"},
+		{"Difference", s1.Difference, s3, "(2 4)"},
+		{"SymmetricDifference", s1.SymmetricDifference, s3, "(2 4 7)"},
+	}
+	for _, test := range tests {
+		if output, err := test.fn(test.other); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if _, ok := output.(*SortedSet); !ok {
+			t.Errorf("%s: got %T, was expecting a sorted set", test.name, output)
+		} else if output.String() != test.expected {
+			t.Errorf("%s: got %s, was expecting %s", test.name, output.String(), test.expected)
+		}
+	}
+
+	if output, _ := s1.Intersection(s2); output.(*SortedSet).Length() != 2 {
+		t.Fatalf("Got %v, which was unexpected", output)
+	} else if value, _ := output.(*SortedSet).At(-1); value != 5 {
+		t.Fatalf("Got %v, was expecting the element from the set", value)
+	}
+
+	bad, _ := MakeSetFromValues("a", []int{1})
+	for _, fn := range []func(SetInterface) (SetInterface, error){s1.Union, s1.Intersection, s1.Difference, s1.SymmetricDifference} {
+		if output, err := fn(bad); err == nil {
+			t.Fatal("Expected comparison error")
+		} else if output != nil {
+			t.Fatalf("Got %T, was expecting a nil set with the error", output)
+		}
+	}
+
+	if ok, err := s1.Disjoint(s2); err != nil || ok {
+		t.Fatal("Expected sets to share elements")
+	} else if ok, err := s1.SupersetOf(s3); err != nil || ok {
+		t.Fatal("Expected s1 not to be a superset of s3")
+	} else if sub, _ := s1.Range(1, 4); sub.String() != "(1 2 3)" {
+		t.Fatalf("Got %v, which was unexpected", sub)
+	} else if ok, err := sub.SubsetOf(s1); err != nil || !ok {
+		t.Fatal("Expected range to be a subset of the set")
+	}
+}
+
+func TestSortedSetQueries(t *testing.T) {
+	s, _ := MakeSortedSetFromValues(10, 20, 30, 40)
+
+	if value, ok := s.Min(); !ok || value != 10 {
+		t.Fatalf("Got %v, was expecting 10", value)
+	} else if value, ok := s.Max(); !ok || value != 40 {
+		t.Fatalf("Got %v, was expecting 40", value)
+	}
+
+	if value, ok, err := s.Floor(25); err != nil || !ok || value != 20 {
+		t.Fatalf("Got %v, was expecting 20", value)
+	} else if value, ok, err := s.Ceiling(25); err != nil || !ok || value != 30 {
+		t.Fatalf("Got %v, was expecting 30", value)
+	} else if _, ok, err := s.Floor(5); err != nil || ok {
+		t.Fatal("Expected no floor")
+	} else if _, ok, err := s.Ceiling(45); err != nil || ok {
+		t.Fatal("Expected no ceiling")
+	}
+
+	if r, err := s.Range(15, 40); err != nil {
+		t.Error(err)
+	} else if r.String() != "(20 30)" {
+		t.Fatalf("Got %s, which was unexpected", r.String())
+	} else if r, err := s.Range(50, 60); err != nil || r.Length() != 0 {
+		t.Fatalf("Got %v, was expecting an empty set", r)
+	} else if _, err := s.Range(10, "z"); err != nil {
+		t.Error(err)
+	} else if _, err := s.Range(10, []int{1}); err == nil {
+		t.Fatal("Expected comparison error")
+	}
+
+	if i, err := s.IndexOf(30); err != nil || i != 2 {
+		t.Fatalf("Got %d, was expecting 2", i)
+	} else if i, err := s.IndexOf(35); err != nil || i != -1 {
+		t.Fatalf("Got %d, was expecting -1", i)
+	}
+
+	if value, err := s.At(0); err != nil || value != 10 {
+		t.Fatalf("Got %v, was expecting 10", value)
+	} else if value, err := s.At(-1); err != nil || value != 40 {
+		t.Fatalf("Got %v, was expecting 40", value)
+	} else if _, err := s.At(4); err == nil {
+		t.Fatal("Expected index error")
+	}
+
+	var empty SortedSet
+	empty.Init()
+	if _, ok := empty.Min(); ok {
+		t.Fatal("Expected empty set to have no minimum")
+	}
+}
+
+func TestSortedSetCompare(t *testing.T) {
+	reverse := func(a interface{}, b interface{}) (int, error) {
+		return b.(int) - a.(int), nil
+	}
+	values, _ := tuple.MakeTupleFromValues(1, 3, 2)
+	s, err := MakeSortedSetWithCompare(reverse, values)
+	if err != nil {
+		t.Fatal(err)
+	} else if s.String() != "(3 2 1)" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	other, _ := MakeSortedSetFromValues(2, 4)
+	if output, err := s.Union(other); err != nil {
+		t.Error(err)
+	} else if output.String() != "(4 3 2 1)" {
+		t.Fatalf("Got %s, which was unexpected", output.String())
+	}
+}
+
+func TestSortedSetEncoding(t *testing.T) {
+	s, _ := MakeSortedSetFromValues(3, 1, 2)
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Error(err)
+	} else if string(b) != "[1,2,3]" {
+		t.Fatalf("Got %s, which was unexpected", b)
+	}
+	decoded := new(SortedSet)
+	if err := json.Unmarshal([]byte("[2,1,2]"), decoded); err != nil {
+		t.Error(err)
+	} else if decoded.String() != "(1 2)" {
+		t.Fatalf("Got %s, which was unexpected", decoded.String())
+	}
+
+	b, err = s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := codec.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if _, ok := v.(*SortedSet); !ok {
+		t.Fatalf("Expected sorted set, got %T", v)
+	}
+	plain := new(Set)
+	if err := plain.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if ok, _ := plain.Equals(s); !ok {
+		t.Fatalf("Got %v, was expecting %v", plain, s)
+	}
+
+	var buf bytes.Buffer
+	var out SetInterface
+	var in SetInterface = s
+	if err := gob.NewEncoder(&buf).Encode(&in); err != nil {
+		t.Fatal(err)
+	} else if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	} else if out.String() != "(1 2 3)" {
+		t.Fatalf("Got %v, which was unexpected", out)
+	}
+}
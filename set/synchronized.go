@@ -0,0 +1,288 @@
+package set
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/dynago/dg/iterable"
+)
+
+// SyncSet is a set which is safe for concurrent use. Iteration works over a snapshot of the set.
+type SyncSet struct {
+	mu  sync.RWMutex
+	set SetInterface
+}
+
+/* Get returns the value given a string representation of the bytes. */
+func (s *SyncSet) Get(hash string) interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Get(hash)
+}
+
+/* Length returns the number of elements in set. */
+func (s *SyncSet) Length() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Length()
+}
+
+/* Iterate returns the next key in a snapshot of the set. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (s *SyncSet) Iterate() <-chan interface{} {
+	return iterable.Channel(s)
+}
+
+/* ForEach calls the function on each element in a snapshot of the set until it returns false. */
+func (s *SyncSet) ForEach(fn func(interface{}) bool) {
+	iter := s.Iterator()
+	defer iter.Close()
+	for iter.Next() {
+		if !fn(iter.Value()) {
+			return
+		}
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the elements in set. */
+func (s *SyncSet) Iterator() iterable.Iterator {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Iterator()
+}
+
+/* Add adds element to the set. */
+func (s *SyncSet) Add(value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Add(value)
+}
+
+/* Remove removes element from the set. */
+func (s *SyncSet) Remove(value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Remove(value)
+}
+
+/* Combine updates the set, adding elements from the other set. */
+func (s *SyncSet) Combine(other SetInterface) error {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Combine(other)
+}
+
+/* Pop pops and return an element from the set; the most recently added one if the wrapped set keeps insertion order. */
+func (s *SyncSet) Pop() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Pop()
+}
+
+/* PopFirst pops and returns the least recently added element from the set. It returns an error if the wrapped set does not keep insertion order. */
+func (s *SyncSet) PopFirst() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.ordered()
+	if err != nil {
+		return nil, err
+	}
+	return o.PopFirst()
+}
+
+/* PopLast pops and returns the most recently added element from the set. It returns an error if the wrapped set does not keep insertion order. */
+func (s *SyncSet) PopLast() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.ordered()
+	if err != nil {
+		return nil, err
+	}
+	return o.PopLast()
+}
+
+/* MoveToEnd moves the element to the end of the set. It returns an error if the wrapped set does not keep insertion order. */
+func (s *SyncSet) MoveToEnd(value interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, err := s.ordered()
+	if err != nil {
+		return err
+	}
+	return o.MoveToEnd(value)
+}
+
+/* Clear clears all elements from the set. */
+func (s *SyncSet) Clear() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Clear()
+}
+
+/* Contains tests for membership in the set. */
+func (s *SyncSet) Contains(value interface{}) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(value)
+}
+
+/* Disjoint returns true if the set has no elements in common with the other set. */
+func (s *SyncSet) Disjoint(other SetInterface) (bool, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Disjoint(other)
+}
+
+/* Equals returns true if the set has all elements in common with the other set. */
+func (s *SyncSet) Equals(other SetInterface) (bool, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Equals(other)
+}
+
+/* HashKey returns the bytes which identify a snapshot of the set. The order of the elements does not change the key. */
+func (s *SyncSet) HashKey() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return hashKey(s.set)
+}
+
+/* EqualTo returns true if the other value is a set with the same elements. */
+func (s *SyncSet) EqualTo(other interface{}) bool {
+	o, ok := other.(SetInterface)
+	if !ok {
+		return false
+	}
+	equals, err := s.Equals(o)
+	return err == nil && equals
+}
+
+/* SupersetOf tests whether every element in the other set is in the set. */
+func (s *SyncSet) SupersetOf(other SetInterface) (bool, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SupersetOf(other)
+}
+
+/* SubsetOf tests whether every element in the set is in the other set. */
+func (s *SyncSet) SubsetOf(other SetInterface) (bool, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return false, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SubsetOf(other)
+}
+
+/* Intersection returns a new set with elements common to the set and all others. */
+func (s *SyncSet) Intersection(other SetInterface) (SetInterface, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Intersection(other)
+}
+
+/* SymmetricDifference returns a new set with elements in either the set or the other but not both. */
+func (s *SyncSet) SymmetricDifference(other SetInterface) (SetInterface, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SymmetricDifference(other)
+}
+
+/* Difference returns a new set with elements in the set that are not in the other set. */
+func (s *SyncSet) Difference(other SetInterface) (SetInterface, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Difference(other)
+}
+
+/* Union returns a new set with elements from the set and the other set. */
+func (s *SyncSet) Union(other SetInterface) (SetInterface, error) {
+	other, err := unwrapSet(other)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Union(other)
+}
+
+/* Copy creates a synchronized copy of the current set. */
+func (s *SyncSet) Copy() (SetInterface, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	output, err := s.set.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return Synchronized(output), nil
+}
+
+/* String returns a string representation of the set. */
+func (s *SyncSet) String() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.String()
+}
+
+/* Init initializes the set. */
+func (s *SyncSet) Init() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.set == nil {
+		s.set = new(Set)
+	}
+	s.set.Init()
+}
+
+/* ordered returns the wrapped set if it keeps insertion order. */
+func (s *SyncSet) ordered() (OrderedSetInterface, error) {
+	o, ok := s.set.(OrderedSetInterface)
+	if !ok {
+		return nil, fmt.Errorf("Set of type %T does not keep insertion order", s.set)
+	}
+	return o, nil
+}
+
+/* unwrapSet returns an unsynchronized copy of the set if it is synchronized, so that it can be read while another lock is held. */
+func unwrapSet(s SetInterface) (SetInterface, error) {
+	syncSet, ok := s.(*SyncSet)
+	if !ok {
+		return s, nil
+	}
+	syncSet.mu.RLock()
+	defer syncSet.mu.RUnlock()
+	return syncSet.set.Copy()
+}
+
+/* Synchronized returns a set which guards the given set with a read/write lock. The given set should not be used directly afterwards. */
+func Synchronized(s SetInterface) SetInterface {
+	return &SyncSet{set: s}
+}
@@ -0,0 +1,64 @@
+package set
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSynchronizedConcurrent(t *testing.T) {
+	s, err := MakeSet()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ss := Synchronized(s)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if err := ss.Add(i*50 + j); err != nil {
+					t.Error(err)
+				}
+				ss.ForEach(func(value interface{}) bool {
+					ok, err := ss.Contains(value)
+					return ok && err == nil
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if ss.Length() != 400 {
+		t.Errorf("Expected 400 elements, got %d", ss.Length())
+	}
+}
+
+func TestSynchronizedSnapshot(t *testing.T) {
+	s, _ := MakeSetFromValues(1, 2, 3)
+	ss := Synchronized(s)
+	count := 0
+	ss.ForEach(func(value interface{}) bool {
+		count++
+		if err := ss.Remove(value); err != nil {
+			t.Error(err)
+		}
+		return true
+	})
+	if count != 3 {
+		t.Errorf("Expected to visit 3 elements, visited %d", count)
+	} else if ss.Length() != 0 {
+		t.Errorf("Expected empty set, got %v", ss)
+	}
+}
+
+func TestSynchronizedSelf(t *testing.T) {
+	s, _ := MakeSetFromValues(1, 2)
+	ss := Synchronized(s)
+	if err := ss.Combine(ss); err != nil {
+		t.Error(err)
+	} else if equals, err := ss.Equals(ss); err != nil || !equals {
+		t.Error("Synchronized set is not equal to itself")
+	} else if u, err := ss.Union(ss); err != nil || u.Length() != 2 {
+		t.Errorf("Expected union of length 2, got %v", u)
+	}
+}
@@ -0,0 +1,41 @@
+package tuple
+
+import (
+	"encoding/gob"
+
+	"github.com/dynago/dg/codec"
+)
+
+func init() {
+	codec.RegisterContainer("tuple", (*Tuple)(nil), func(t interface{}) ([]interface{}, error) {
+		return t.(*Tuple).values, nil
+	}, func(values []interface{}) (interface{}, error) {
+		return MakeTupleFromValues(values...)
+	})
+	gob.Register(new(Tuple))
+}
+
+/* MarshalBinary encodes the tuple, preserving the type of every element. */
+func (t *Tuple) MarshalBinary() ([]byte, error) {
+	return codec.Marshal(t)
+}
+
+/* UnmarshalBinary decodes a tuple encoded by MarshalBinary into the tuple. */
+func (t *Tuple) UnmarshalBinary(data []byte) error {
+	values, err := codec.UnmarshalElements(data, "tuple")
+	if err != nil {
+		return err
+	}
+	t.values = values
+	return nil
+}
+
+/* GobEncode encodes the tuple for encoding/gob. */
+func (t *Tuple) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+/* GobDecode decodes the tuple for encoding/gob. */
+func (t *Tuple) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
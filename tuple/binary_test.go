@@ -0,0 +1,41 @@
+package tuple
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestBinary(t *testing.T) {
+	inner, _ := MakeTupleFromValues(int32(1), "a")
+	tup, _ := MakeTupleFromValues(uint(1), 2.5, inner, nil)
+	b, err := tup.(*Tuple).MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoded := new(Tuple)
+	if err = decoded.UnmarshalBinary(b); err != nil {
+		t.Error(err)
+	} else if equals, _ := decoded.Equals(tup); !equals {
+		t.Errorf("Expected %v, got %v", tup, decoded)
+	} else if v, _ := decoded.Get(2); v == nil {
+		t.Error("Expected nested tuple")
+	} else if _, ok := v.(*Tuple); !ok {
+		t.Errorf("Expected nested tuple, got %T", v)
+	}
+}
+
+func TestGob(t *testing.T) {
+	inner, _ := MakeTupleFromValues(int8(1))
+	tup, _ := MakeTupleFromValues("a", inner)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(struct{ T TupleInterface }{tup}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded struct{ T TupleInterface }
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if equals, _ := decoded.T.Equals(tup); !equals {
+		t.Errorf("Expected %v, got %v", tup, decoded.T)
+	}
+}
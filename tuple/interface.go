@@ -1,11 +1,17 @@
 package tuple
 
+import "github.com/dynago/dg/iterable"
+
 // TupleInterface is the interface which defines whether a struct is a tuple or not.
 type TupleInterface interface {
 	/* Return the number of elements in tuple. */
 	Length() int
 	/* Return the next value in tuple. */
 	Iterate() <-chan interface{}
+	/* Call the function on each value in tuple until it returns false. */
+	ForEach(func(interface{}) bool)
+	/* Return an Iterator over the values in tuple. */
+	Iterator() iterable.Iterator
 
 	/* Test for membership in the tuple. */
 	Contains(interface{}) (bool, error)
@@ -21,6 +27,8 @@ type TupleInterface interface {
 	Get(int) (interface{}, error)
 	/* Returns the a tuple of values given range. */
 	Range(int, int) (TupleInterface, error)
+	/* Returns every step-th value from start up to but not including stop, as in Python. */
+	Slice(int, int, int) (TupleInterface, error)
 	/* Return first index of value. Returns -1 if not found. */
 	Index(interface{}) (int, error)
 	/* Return count of value. */
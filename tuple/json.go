@@ -0,0 +1,27 @@
+package tuple
+
+import "github.com/dynago/dg/internal/jsonvalue"
+
+func init() {
+	jsonvalue.MakeTuple = func(values []interface{}) (interface{}, error) {
+		return MakeTupleFromValues(values...)
+	}
+}
+
+/* MarshalJSON encodes the tuple as a JSON array. */
+func (t *Tuple) MarshalJSON() ([]byte, error) {
+	return jsonvalue.EncodeArray(t.values, false)
+}
+
+/* UnmarshalJSON decodes a JSON array into the tuple. Nested arrays become tuples, so that the tuple stays hashable, and nested objects become dicts. */
+func (t *Tuple) UnmarshalJSON(data []byte) error {
+	if jsonvalue.IsNull(data) {
+		return nil
+	}
+	values, err := jsonvalue.DecodeArray(data, true)
+	if err != nil {
+		return err
+	}
+	t.values = values
+	return nil
+}
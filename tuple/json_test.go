@@ -0,0 +1,25 @@
+package tuple
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	inner, _ := MakeTupleFromValues("a", nil)
+	tup, _ := MakeTupleFromValues(1, 2.5, inner)
+	b, err := json.Marshal(tup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if string(b) != `[1,2.5,["a",null]]` {
+		t.Errorf("Expected array, got %s", b)
+	}
+	decoded := new(Tuple)
+	if err = json.Unmarshal(b, decoded); err != nil {
+		t.Error(err)
+	} else if equals, _ := decoded.Equals(tup); !equals {
+		t.Errorf("Expected %v, got %v", tup, decoded)
+	} else if err := json.Unmarshal([]byte(`[1,`), decoded); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+}
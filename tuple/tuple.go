@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/dynago/dg/equality"
+	"github.com/dynago/dg/errs"
+	"github.com/dynago/dg/hasher"
 	"github.com/dynago/dg/internal/helpers"
-	"github.com/dynago/dg/internal/iterable"
+	"github.com/dynago/dg/iterable"
+	"github.com/dynago/dg/order"
 )
 
 // Tuple is a dynamic tuple structure.
@@ -19,22 +23,29 @@ func (t *Tuple) Length() int {
 	return len(t.values)
 }
 
-/* Iterate returns the next key in the tuple. */
+/* Iterate returns the next key in the tuple. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
 func (t *Tuple) Iterate() <-chan interface{} {
-	c := make(chan interface{})
-	go func() {
-		for _, v := range t.values {
-			c <- v
+	return iterable.Channel(t)
+}
+
+/* ForEach calls the function on each value in the tuple until it returns false. */
+func (t *Tuple) ForEach(fn func(interface{}) bool) {
+	for _, v := range t.values {
+		if !fn(v) {
+			return
 		}
-		close(c)
-	}()
-	return c
+	}
+}
+
+/* Iterator returns an Iterator over a snapshot of the values in the tuple. */
+func (t *Tuple) Iterator() iterable.Iterator {
+	return iterable.FromSlice(append([]interface{}(nil), t.values...))
 }
 
 /* Contains tests for membership in the tuple. */
 func (t *Tuple) Contains(value interface{}) (bool, error) {
 	for _, v := range t.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			return true, nil
 		}
 	}
@@ -51,7 +62,7 @@ func (t *Tuple) Equals(other TupleInterface) (bool, error) {
 		if err != nil {
 			return false, err
 		}
-		if tv != ov {
+		if !equality.Equal(tv, ov) {
 			return false, nil
 		}
 	}
@@ -62,10 +73,11 @@ func (t *Tuple) Equals(other TupleInterface) (bool, error) {
 func (t *Tuple) Concatenate(other TupleInterface) (TupleInterface, error) {
 	output := new(Tuple)
 	output.Init()
-	output.values = t.values
-	for value := range other.Iterate() {
+	output.values = append(make([]interface{}, 0, len(t.values)+other.Length()), t.values...)
+	other.ForEach(func(value interface{}) bool {
 		output.values = append(output.values, value)
-	}
+		return true
+	})
 	return output, nil
 }
 
@@ -79,16 +91,17 @@ func (t *Tuple) Multiply(n int) (TupleInterface, error) {
 	return output, nil
 }
 
-/* Get returns the value at index. */
+/* Get returns the value at index. Negative indexes count from the end of the tuple. */
 func (t *Tuple) Get(i int) (interface{}, error) {
-	if i >= len(t.values) || i < 0 {
-		return nil, fmt.Errorf("Index i out of range of tuple")
+	j, ok := helpers.ElementIndex(i, len(t.values))
+	if !ok {
+		return nil, &errs.IndexError{Index: i, Length: len(t.values), Structure: "tuple"}
 	}
 
-	return t.values[i], nil
+	return t.values[j], nil
 }
 
-/* Range returns the a tuple of values given range. */
+/* Range returns the a tuple of values given range. Negative indexes count from the end of the tuple. */
 func (t *Tuple) Range(start int, end int) (TupleInterface, error) {
 	start = helpers.ValidIndex(start, len(t.values))
 	end = helpers.ValidIndex(end, len(t.values))
@@ -101,10 +114,23 @@ func (t *Tuple) Range(start int, end int) (TupleInterface, error) {
 	return output, nil
 }
 
+/* Slice returns a tuple of every step-th value from start up to but not including stop, with the same semantics as Python's t[start:stop:step]. Negative indexes count from the end of the tuple, and math.MinInt or math.MaxInt can be used for an open bound. */
+func (t *Tuple) Slice(start int, stop int, step int) (TupleInterface, error) {
+	first, count, err := helpers.SliceIndices(start, stop, step, len(t.values))
+	if err != nil {
+		return nil, err
+	}
+	output := &Tuple{values: make([]interface{}, count)}
+	for k := range output.values {
+		output.values[k] = t.values[first+k*step]
+	}
+	return output, nil
+}
+
 /* Index returns first index of value. Returns -1 if not found. */
 func (t *Tuple) Index(value interface{}) (int, error) {
 	for i, v := range t.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			return i, nil
 		}
 	}
@@ -115,13 +141,58 @@ func (t *Tuple) Index(value interface{}) (int, error) {
 func (t *Tuple) Count(value interface{}) (int, error) {
 	count := 0
 	for _, v := range t.values {
-		if v == value {
+		if equality.Equal(v, value) {
 			count += 1
 		}
 	}
 	return count, nil
 }
 
+/* HashKey returns the bytes which identify the tuple. The elements are hashed in order, so tuples with equal elements in the same order have equal keys. */
+func (t *Tuple) HashKey() ([]byte, error) {
+	h := hasher.Default()
+	output := make([]byte, 0)
+	for _, value := range t.values {
+		if value != nil {
+			hash, err := hasher.Hash(h, value)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, hash...)
+		}
+		output = append(output, ',')
+	}
+	return output, nil
+}
+
+/* EqualTo returns true if the other value is a tuple with equal elements. */
+func (t *Tuple) EqualTo(other interface{}) bool {
+	o, ok := other.(TupleInterface)
+	if !ok {
+		return false
+	}
+	equals, err := t.Equals(o)
+	return err == nil && equals
+}
+
+/* CompareTo compares the tuple with another tuple element by element, as in order.Compare. A tuple which is a prefix of the other is before it. */
+func (t *Tuple) CompareTo(other interface{}) (int, error) {
+	o, ok := other.(TupleInterface)
+	if !ok {
+		return 0, &order.IncomparableError{A: t, B: other}
+	}
+	for i := 0; i < len(t.values) && i < o.Length(); i++ {
+		value, err := o.Get(i)
+		if err != nil {
+			return 0, err
+		}
+		if c, err := order.Compare(t.values[i], value); err != nil || c != 0 {
+			return c, err
+		}
+	}
+	return order.Compare(len(t.values), o.Length())
+}
+
 /* Copy creates a copy of the current TupleInterface. */
 func (t *Tuple) Copy() (TupleInterface, error) {
 	output, err := MakeTuple(t)
@@ -148,10 +219,10 @@ func MakeTuple(it ...iterable.Iterable) (TupleInterface, error) {
 	output := new(Tuple)
 	output.Init()
 	if len(it) > 0 {
-		i := it[0]
-		for val := range i.Iterate() {
+		iterable.ForEach(it[0], func(val interface{}) bool {
 			output.values = append(output.values, val)
-		}
+			return true
+		})
 	}
 	return output, nil
 }
@@ -1,8 +1,11 @@
 package tuple
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/dynago/dg/errs"
 )
 
 func printChecker(str string, values []string) bool {
@@ -147,6 +150,18 @@ func TestEquals(t *testing.T) {
 	}
 }
 
+func TestConcatenateCopies(t *testing.T) {
+	s, _ := MakeTupleFromValues(1, 2, 3, 4, 5)
+	a, _ := MakeTupleFromValues("a")
+	b, _ := MakeTupleFromValues("b")
+	c1, _ := s.Concatenate(a)
+	c2, _ := c1.Concatenate(a)
+	c3, _ := c1.Concatenate(b)
+	if c2.String() != "(1 2 3 4 5 a a)" || c3.String() != "(1 2 3 4 5 a b)" {
+		t.Fatalf("Got %v and %v, was expecting concatenations not to share values", c2, c3)
+	}
+}
+
 func TestConcatenate(t *testing.T) {
 	s1, err1 := MakeTupleFromValues(1, 2.2, "hello")
 	if err1 != nil {
@@ -243,10 +258,16 @@ func TestGet(t *testing.T) {
 		t.Error(err2)
 	}
 
-	if _, err := s1.Get(-1); err == nil {
+	if _, err := s1.Get(-4); err == nil {
 		t.Fatalf("Was expecting index to be out of range")
 	}
 
+	if val, err := s1.Get(-1); err != nil {
+		t.Error(err)
+	} else if val != "hello" {
+		t.Fatalf("Got %s, was expecting hello", val)
+	}
+
 	if val, err := s1.Get(0); err != nil {
 		t.Error(err)
 	} else if val != 1 {
@@ -265,8 +286,11 @@ func TestGet(t *testing.T) {
 		t.Fatalf("Got %s, was expecting hello", val)
 	}
 
-	if _, err := s1.Get(3); err == nil {
-		t.Fatalf("Was expecting index to be out of range")
+	var indexErr *errs.IndexError
+	if _, err := s1.Get(3); !errors.As(err, &indexErr) {
+		t.Fatalf("Got %v, was expecting an IndexError", err)
+	} else if indexErr.Index != 3 || indexErr.Length != 3 || !errors.Is(err, errs.ErrIndexOutOfRange) {
+		t.Fatalf("Got %+v, was expecting index 3 of length 3", indexErr)
 	}
 
 	if _, err := s2.Get(0); err == nil {
@@ -305,7 +329,13 @@ func TestRange(t *testing.T) {
 
 	if s, err := s1.Range(-1, 4); err != nil {
 		t.Error(err)
-	} else if ok := printChecker(s.String(), []string{"1", "2.2", "hello"}); !ok {
+	} else if ok := printChecker(s.String(), []string{"hello"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Range(-5, -1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "2.2"}); !ok {
 		t.Fatalf("Got %s, which was unexpected", s.String())
 	}
 
@@ -322,6 +352,29 @@ func TestRange(t *testing.T) {
 	}
 }
 
+func TestSlice(t *testing.T) {
+	s1, err1 := MakeTupleFromValues(0, 1, 2, 3, 4, 5)
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	if s, err := s1.Slice(1, 5, 2); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"1", "3"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if s, err := s1.Slice(-1, -7, -1); err != nil {
+		t.Error(err)
+	} else if ok := printChecker(s.String(), []string{"5", "4", "3", "2", "1", "0"}); !ok {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if _, err := s1.Slice(0, 6, 0); err == nil {
+		t.Fatalf("Expected step error")
+	}
+}
+
 func TestIndex(t *testing.T) {
 	s1, err1 := MakeTupleFromValues(1, 2.2, "hello", 2.2)
 	if err1 != nil {
@@ -405,3 +458,133 @@ func TestCount(t *testing.T) {
 		t.Fatalf("Got %d, was expecting 0", i)
 	}
 }
+
+func TestHashKey(t *testing.T) {
+	s1, err1 := MakeTupleFromValues(1, "a")
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	s2, err2 := MakeTupleFromValues(1, "a")
+	if err2 != nil {
+		t.Error(err2)
+	}
+
+	s3, err3 := MakeTupleFromValues("a", 1)
+	if err3 != nil {
+		t.Error(err3)
+	}
+
+	s4, err4 := MakeTupleFromValues(s1, nil)
+	if err4 != nil {
+		t.Error(err4)
+	}
+
+	s5, err5 := MakeTupleFromValues(s2, nil)
+	if err5 != nil {
+		t.Error(err5)
+	}
+
+	k1, _ := s1.(*Tuple).HashKey()
+	k2, _ := s2.(*Tuple).HashKey()
+	k3, _ := s3.(*Tuple).HashKey()
+	if string(k1) != string(k2) {
+		t.Fatal("s1 and s2 should have equal keys, but they do not")
+	}
+	if string(k1) == string(k3) {
+		t.Fatal("s1 and s3 should not have equal keys, but they do")
+	}
+
+	k4, err := s4.(*Tuple).HashKey()
+	if err != nil {
+		t.Error(err)
+	}
+	k5, err := s5.(*Tuple).HashKey()
+	if err != nil {
+		t.Error(err)
+	}
+	if string(k4) != string(k5) {
+		t.Fatal("s4 and s5 should have equal keys, but they do not")
+	}
+
+	if equals, err := s4.Equals(s5); err != nil {
+		t.Error(err)
+	} else if equals == false {
+		t.Fatal("s4 and s5 should be equal, but they are not")
+	}
+}
+
+func TestCompareTo(t *testing.T) {
+	s1, _ := MakeTupleFromValues(1, "a")
+	s2, _ := MakeTupleFromValues(1, "b")
+	s3, _ := MakeTupleFromValues(1)
+	s4, _ := MakeTupleFromValues(1.0, "a")
+
+	if c, err := s1.(*Tuple).CompareTo(s2); err != nil {
+		t.Error(err)
+	} else if c != -1 {
+		t.Fatalf("Got %d, was expecting -1", c)
+	}
+
+	if c, err := s1.(*Tuple).CompareTo(s3); err != nil {
+		t.Error(err)
+	} else if c != 1 {
+		t.Fatalf("Got %d, was expecting 1", c)
+	}
+
+	if c, err := s1.(*Tuple).CompareTo(s4); err != nil {
+		t.Error(err)
+	} else if c != 0 {
+		t.Fatalf("Got %d, was expecting 0", c)
+	}
+
+	if _, err := s1.(*Tuple).CompareTo([]interface{}{1, "a"}); err == nil {
+		t.Fatalf("Expected incomparable error")
+	}
+}
+
+func TestDeepEquality(t *testing.T) {
+	s1, err1 := MakeTupleFromValues([]int{1, 2}, "hello")
+	if err1 != nil {
+		t.Error(err1)
+	}
+
+	if contains, err := s1.Contains([]int{1, 2}); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The tuple does not contain [1 2] when it should")
+	}
+
+	if i, err := s1.Index([]int{2, 1}); err != nil {
+		t.Error(err)
+	} else if i != -1 {
+		t.Fatalf("Got %d, was expecting -1", i)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	s, err := MakeTupleFromValues(1, 2.2, "hello")
+	if err != nil {
+		t.Error(err)
+	}
+
+	values := make([]interface{}, 0)
+	s.ForEach(func(value interface{}) bool {
+		values = append(values, value)
+		return true
+	})
+	if ok := printChecker(fmt.Sprint(values), []string{"1", "2.2", "hello"}); !ok {
+		t.Fatalf("Got %v, which was unexpected", values)
+	}
+
+	iter := s.Iterator()
+	if !iter.Next() {
+		t.Fatal("Iterator should have a value, but it does not")
+	} else if iter.Value() != 1 {
+		t.Fatalf("Got %v, was expecting 1", iter.Value())
+	}
+	iter.Close()
+	if iter.Next() {
+		t.Fatal("Iterator should be closed, but it is not")
+	}
+}
@@ -0,0 +1,238 @@
+package typed
+
+import (
+	"github.com/dynago/dg/dict"
+	"github.com/dynago/dg/tuple"
+)
+
+// Dict is a dict whose keys are all of type K and whose values are all of type V.
+type Dict[K comparable, V any] struct {
+	dict dict.DictInterface
+}
+
+/* Length returns the number of elements in dict. */
+func (d *Dict[K, V]) Length() int {
+	return d.dict.Length()
+}
+
+/* Iterate returns the next key in dict. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (d *Dict[K, V]) Iterate() <-chan K {
+	return channel[K](d.ForEach)
+}
+
+/* ForEach calls the function on each key in dict until it returns false. */
+func (d *Dict[K, V]) ForEach(fn func(K) bool) {
+	forEach(d.dict, fn)
+}
+
+/* Iterator returns an Iterator over a snapshot of the keys in dict. */
+func (d *Dict[K, V]) Iterator() Iterator[K] {
+	return &iterator[K]{d.dict.Iterator()}
+}
+
+/* Remove removes element from the dict. */
+func (d *Dict[K, V]) Remove(key K) error {
+	return d.dict.Remove(key)
+}
+
+/* Get returns the value with given key. */
+func (d *Dict[K, V]) Get(key K) (V, error) {
+	value, err := d.dict.Get(key)
+	if err != nil || value == nil {
+		var zero V
+		return zero, err
+	}
+	return cast[V](value)
+}
+
+/* Lookup returns the value with given key, and false if the key is not in the dict. */
+func (d *Dict[K, V]) Lookup(key K) (V, bool, error) {
+	var zero V
+	value, found, err := d.dict.Lookup(key)
+	if err != nil || !found || value == nil {
+		return zero, found, err
+	}
+	v, err := cast[V](value)
+	if err != nil {
+		return zero, false, err
+	}
+	return v, true, nil
+}
+
+/* GetOr returns the value with given key, or the default if the key is not in the dict. */
+func (d *Dict[K, V]) GetOr(key K, def V) (V, error) {
+	value, found, err := d.Lookup(key)
+	if err != nil || !found {
+		return def, err
+	}
+	return value, nil
+}
+
+/* Set sets the value at given key to given value. */
+func (d *Dict[K, V]) Set(key K, value V) error {
+	return d.dict.Set(key, value)
+}
+
+/* Combine updates the dict, adding elements from the other dict. Old values are replaced with new. */
+func (d *Dict[K, V]) Combine(other *Dict[K, V]) error {
+	return d.dict.Combine(other.dict)
+}
+
+/* PopKey pops and returns an arbitrary key from the dict, or dict.ErrEmpty if it is empty. */
+func (d *Dict[K, V]) PopKey() (K, error) {
+	key, _, err := d.Pop()
+	return key, err
+}
+
+/* PopValue pops and returns an arbitrary value from the dict, or dict.ErrEmpty if it is empty. */
+func (d *Dict[K, V]) PopValue() (V, error) {
+	_, value, err := d.Pop()
+	return value, err
+}
+
+/* Pop pops and returns an arbitrary item from the dict, or dict.ErrEmpty if it is empty. */
+func (d *Dict[K, V]) Pop() (K, V, error) {
+	var zeroK K
+	var zeroV V
+	key, value, err := d.dict.Pop()
+	if err != nil || key == nil {
+		return zeroK, zeroV, err
+	}
+	k, err := cast[K](key)
+	if err != nil {
+		return zeroK, zeroV, err
+	}
+	if value == nil {
+		return k, zeroV, nil
+	}
+	v, err := cast[V](value)
+	if err != nil {
+		return zeroK, zeroV, err
+	}
+	return k, v, nil
+}
+
+/* Clear clears all elements from the dict. */
+func (d *Dict[K, V]) Clear() error {
+	return d.dict.Clear()
+}
+
+/* Contains tests for membership in the dict. */
+func (d *Dict[K, V]) Contains(key K) (bool, error) {
+	return d.dict.Contains(key)
+}
+
+/* Equals returns true if the dict has all elements in common with the other dict. */
+func (d *Dict[K, V]) Equals(other *Dict[K, V]) (bool, error) {
+	return d.dict.Equals(other.dict)
+}
+
+/* Keys returns a tuple of keys. */
+func (d *Dict[K, V]) Keys() (*Tuple[K], error) {
+	keys, err := d.dict.Keys()
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[K]{keys}, nil
+}
+
+/* Values returns a tuple of values. */
+func (d *Dict[K, V]) Values() (*Tuple[V], error) {
+	values, err := d.dict.Values()
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[V]{values}, nil
+}
+
+/* Items returns a tuple of key/value pairs. */
+func (d *Dict[K, V]) Items() (*Tuple[Pair[K, V]], error) {
+	items, err := d.dict.Items()
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([]Pair[K, V], 0, items.Length())
+	items.ForEach(func(item interface{}) bool {
+		var pair Pair[K, V]
+		if pair, err = PairFrom[K, V](item.(tuple.TupleInterface)); err != nil {
+			return false
+		}
+		pairs = append(pairs, pair)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MakeTuple(pairs...)
+}
+
+/* Copy creates a copy of the current dict. */
+func (d *Dict[K, V]) Copy() (*Dict[K, V], error) {
+	output, err := d.dict.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Dict[K, V]{output}, nil
+}
+
+/* String returns a string representation of the dict. */
+func (d *Dict[K, V]) String() string {
+	return d.dict.String()
+}
+
+/* Dynamic returns a copy of the dict as a dynamic dict. */
+func (d *Dict[K, V]) Dynamic() (dict.DictInterface, error) {
+	return d.dict.Copy()
+}
+
+/* MakeDict initializes a new typed dict object using a Go map. */
+func MakeDict[K comparable, V any](values map[K]V) (*Dict[K, V], error) {
+	output, err := dict.MakeDict()
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range values {
+		if err = output.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return &Dict[K, V]{output}, nil
+}
+
+/* MakeDictFromItems initializes a new typed dict object using key/value pairs. */
+func MakeDictFromItems[K comparable, V any](items ...Pair[K, V]) (*Dict[K, V], error) {
+	output, err := dict.MakeDict()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if err = output.Set(item.First, item.Second); err != nil {
+			return nil, err
+		}
+	}
+	return &Dict[K, V]{output}, nil
+}
+
+/* DictFrom initializes a new typed dict object with the items of a dynamic dict. Returns an error if any key is not a K or any value is not a V. */
+func DictFrom[K comparable, V any](d dict.DictInterface) (*Dict[K, V], error) {
+	var err error
+	d.ForEach(func(key interface{}) bool {
+		if _, err = cast[K](key); err != nil {
+			return false
+		}
+		var value interface{}
+		if value, err = d.Get(key); err != nil {
+			return false
+		}
+		_, err = cast[V](value)
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	output, err := d.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Dict[K, V]{output}, nil
+}
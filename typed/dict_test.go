@@ -0,0 +1,83 @@
+package typed
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dynago/dg/dict"
+)
+
+func TestDict(t *testing.T) {
+	s, err := MakeDict(map[string]int{"a": 1})
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := s.Set("b", 2); err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get("b"); err != nil {
+		t.Error(err)
+	} else if value != 2 {
+		t.Fatalf("Got %d, was expecting 2", value)
+	}
+	if value, err := s.Get("c"); err != nil {
+		t.Error(err)
+	} else if value != 0 {
+		t.Fatalf("Got %d, was expecting 0", value)
+	}
+
+	items, err := s.Items()
+	if err != nil {
+		t.Error(err)
+	}
+	sum := 0
+	items.ForEach(func(item Pair[string, int]) bool {
+		sum += item.Second
+		return true
+	})
+	if sum != 3 {
+		t.Fatalf("Got %d, was expecting 3", sum)
+	}
+
+	key, value, err := s.Pop()
+	if err != nil {
+		t.Error(err)
+	} else if (key != "a" || value != 1) && (key != "b" || value != 2) {
+		t.Fatalf("Pop popped an unexpected item: (%s %d)", key, value)
+	}
+
+	if value, found, err := s.Lookup("a"); err != nil || !found || value != 1 {
+		t.Fatalf("Got %d and %v, was expecting 1 and true", value, found)
+	} else if value, found, err := s.Lookup("c"); err != nil || found || value != 0 {
+		t.Fatalf("Got %d and %v, was expecting 0 and false", value, found)
+	} else if value, err := s.GetOr("c", -1); err != nil || value != -1 {
+		t.Fatalf("Got %d, was expecting -1", value)
+	}
+
+	s.Clear()
+	if _, _, err := s.Pop(); !errors.Is(err, dict.ErrEmpty) {
+		t.Fatalf("Got %v, was expecting dict.ErrEmpty", err)
+	}
+}
+
+func TestDictConversion(t *testing.T) {
+	d, err := dict.MakeDictFromKeyValues([]interface{}{"a", "b"}, []interface{}{1, 2})
+	if err != nil {
+		t.Error(err)
+	}
+
+	s, err := DictFrom[string, int](d)
+	if err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get("a"); err != nil {
+		t.Error(err)
+	} else if value != 1 {
+		t.Fatalf("Got %d, was expecting 1", value)
+	}
+
+	if _, err := DictFrom[string, string](d); err == nil {
+		t.Fatal("Expected error converting dict of ints to dict of strings")
+	}
+}
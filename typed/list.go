@@ -0,0 +1,248 @@
+package typed
+
+import "github.com/dynago/dg/list"
+
+// List is a list whose elements are all of type T.
+type List[T any] struct {
+	list list.ListInterface
+}
+
+/* Length returns the number of elements in list. */
+func (l *List[T]) Length() int {
+	return l.list.Length()
+}
+
+/* Iterate returns the next value in list. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (l *List[T]) Iterate() <-chan T {
+	return channel[T](l.ForEach)
+}
+
+/* ForEach calls the function on each value in list until it returns false. */
+func (l *List[T]) ForEach(fn func(T) bool) {
+	forEach(l.list, fn)
+}
+
+/* Iterator returns an Iterator over the values in list. */
+func (l *List[T]) Iterator() Iterator[T] {
+	return &iterator[T]{l.list.Iterator()}
+}
+
+/* Contains tests for membership in the list. */
+func (l *List[T]) Contains(value T) (bool, error) {
+	return l.list.Contains(value)
+}
+
+/* Equals returns true if the list has all elements in common with the other list. */
+func (l *List[T]) Equals(other *List[T]) (bool, error) {
+	return l.list.Equals(other.list)
+}
+
+/* Concatenate returns concatenation of two lists together. */
+func (l *List[T]) Concatenate(other *List[T]) (*List[T], error) {
+	output, err := l.list.Concatenate(other.list)
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Multiply returns list repeated n times. */
+func (l *List[T]) Multiply(n int) (*List[T], error) {
+	output, err := l.list.Multiply(n)
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Reverse returns reversed list. */
+func (l *List[T]) Reverse() (*List[T], error) {
+	output, err := l.list.Reverse()
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Sort sorts the list in place, stably, using the default ordering if less is nil. */
+func (l *List[T]) Sort(less func(a T, b T) bool) error {
+	if less == nil {
+		return l.list.Sort(nil)
+	}
+	return l.list.Sort(func(a interface{}, b interface{}) bool {
+		x, _ := a.(T)
+		y, _ := b.(T)
+		return less(x, y)
+	})
+}
+
+/* SortBy sorts the list in place, stably, by the default ordering of the key of each value. */
+func (l *List[T]) SortBy(key func(T) interface{}) error {
+	return l.list.SortBy(func(value interface{}) interface{} {
+		v, _ := value.(T)
+		return key(v)
+	})
+}
+
+/* Sorted returns a new list with the values of the list in the default ordering. */
+func (l *List[T]) Sorted() (*List[T], error) {
+	output, err := l.list.Sorted()
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Get returns the value at index. */
+func (l *List[T]) Get(i int) (T, error) {
+	value, err := l.list.Get(i)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return cast[T](value)
+}
+
+/* Range returns the a list of values given range. */
+func (l *List[T]) Range(start int, end int) (*List[T], error) {
+	output, err := l.list.Range(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Slice returns a list of every step-th value from start up to but not including stop, with the same semantics as Python's l[start:stop:step]. */
+func (l *List[T]) Slice(start int, stop int, step int) (*List[T], error) {
+	output, err := l.list.Slice(start, stop, step)
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* Index returns first index of value. Returns -1 if not found. */
+func (l *List[T]) Index(value T) (int, error) {
+	return l.list.Index(value)
+}
+
+/* Count returns count of value. */
+func (l *List[T]) Count(value T) (int, error) {
+	return l.list.Count(value)
+}
+
+/* Insert inserts the value before index i, shifting later values right. Negative indexes count from the end of the list, and indexes out of range insert at the start or end. */
+func (l *List[T]) Insert(i int, value T) error {
+	return l.list.Insert(i, value)
+}
+
+/* InsertAll inserts the values before index i, shifting later values right. Indexes are treated as in Insert. */
+func (l *List[T]) InsertAll(i int, values ...T) error {
+	other, err := list.MakeListFromValues(toInterfaces(values)...)
+	if err != nil {
+		return err
+	}
+	return l.list.InsertAll(i, other)
+}
+
+/* Extend appends the values to the end of the list. */
+func (l *List[T]) Extend(values ...T) error {
+	other, err := list.MakeListFromValues(toInterfaces(values)...)
+	if err != nil {
+		return err
+	}
+	return l.list.Extend(other)
+}
+
+/* Set sets values in given range to the given values. */
+func (l *List[T]) Set(start int, end int, values ...T) error {
+	other, err := list.MakeListFromValues(toInterfaces(values)...)
+	if err != nil {
+		return err
+	}
+	return l.list.Set(start, end, other)
+}
+
+/* SetSlice replaces the values in the slice start:stop:step with the given values, with the same semantics as Python's l[start:stop:step] = values. */
+func (l *List[T]) SetSlice(start int, stop int, step int, values ...T) error {
+	other, err := list.MakeListFromValues(toInterfaces(values)...)
+	if err != nil {
+		return err
+	}
+	return l.list.SetSlice(start, stop, step, other)
+}
+
+/* Remove removes first occurrence of the element from the list. */
+func (l *List[T]) Remove(value T) error {
+	return l.list.Remove(value)
+}
+
+/* Delete removes range from the list. Takes two parameters: start (int), end (int: optional). */
+func (l *List[T]) Delete(start int, end ...int) error {
+	return l.list.Delete(start, end...)
+}
+
+/* DeleteSlice removes the values in the slice start:stop:step, with the same semantics as Python's del l[start:stop:step]. */
+func (l *List[T]) DeleteSlice(start int, stop int, step int) error {
+	return l.list.DeleteSlice(start, stop, step)
+}
+
+/* Append appends element to the end of the list. */
+func (l *List[T]) Append(value T) error {
+	return l.list.Append(value)
+}
+
+/* Pop pops and returns the last element from the list. */
+func (l *List[T]) Pop() (T, error) {
+	value, err := l.list.Pop()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return cast[T](value)
+}
+
+/* Clear clears all elements from the list. */
+func (l *List[T]) Clear() error {
+	return l.list.Clear()
+}
+
+/* Copy creates a copy of the current list. */
+func (l *List[T]) Copy() (*List[T], error) {
+	output, err := l.list.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* String returns a string representation of the list. */
+func (l *List[T]) String() string {
+	return l.list.String()
+}
+
+/* Dynamic returns a copy of the list as a dynamic list. */
+func (l *List[T]) Dynamic() (list.ListInterface, error) {
+	return l.list.Copy()
+}
+
+/* MakeList initializes a new typed list object using any number of values. */
+func MakeList[T any](values ...T) (*List[T], error) {
+	output, err := list.MakeListFromValues(toInterfaces(values)...)
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
+
+/* ListFrom initializes a new typed list object with the values of a dynamic list. Returns an error if any value is not a T. */
+func ListFrom[T any](l list.ListInterface) (*List[T], error) {
+	if err := checkAll[T](l); err != nil {
+		return nil, err
+	}
+	output, err := l.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &List[T]{output}, nil
+}
@@ -0,0 +1,116 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/dynago/dg/list"
+)
+
+func TestList(t *testing.T) {
+	s, err := MakeList(1, 2, 3)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if err := s.Append(4); err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get(3); err != nil {
+		t.Error(err)
+	} else if value != 4 {
+		t.Fatalf("Got %d, was expecting 4", value)
+	}
+
+	sum := 0
+	s.ForEach(func(value int) bool {
+		sum += value
+		return true
+	})
+	if sum != 10 {
+		t.Fatalf("Got %d, was expecting 10", sum)
+	}
+
+	r, err := s.Reverse()
+	if err != nil {
+		t.Error(err)
+	}
+	if value, err := r.Pop(); err != nil {
+		t.Error(err)
+	} else if value != 1 {
+		t.Fatalf("Got %d, was expecting 1", value)
+	}
+
+	if s.String() != "[1 2 3 4]" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if err := s.Insert(0, 0); err != nil {
+		t.Error(err)
+	} else if err := s.InsertAll(-1, 5, 6); err != nil {
+		t.Error(err)
+	} else if err := s.Extend(7); err != nil {
+		t.Error(err)
+	} else if s.String() != "[0 1 2 3 5 6 4 7]" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if r, err := s.Slice(-1, 0, -3); err != nil {
+		t.Error(err)
+	} else if r.String() != "[7 5 1]" {
+		t.Fatalf("Got %s, which was unexpected", r.String())
+	}
+	if err := s.SetSlice(0, 2, 1, 9); err != nil {
+		t.Error(err)
+	} else if err := s.DeleteSlice(1, 7, 2); err != nil {
+		t.Error(err)
+	} else if s.String() != "[9 3 6 7]" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+
+	if err := s.Sort(func(a int, b int) bool { return a > b }); err != nil {
+		t.Error(err)
+	} else if s.String() != "[9 7 6 3]" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+	if err := s.SortBy(func(value int) interface{} { return value % 3 }); err != nil {
+		t.Error(err)
+	} else if s.String() != "[9 6 3 7]" {
+		t.Fatalf("Got %s, which was unexpected", s.String())
+	}
+	if r, err := s.Sorted(); err != nil {
+		t.Error(err)
+	} else if r.String() != "[3 6 7 9]" {
+		t.Fatalf("Got %s, which was unexpected", r.String())
+	}
+}
+
+func TestListConversion(t *testing.T) {
+	d, err := list.MakeListFromValues("a", "b")
+	if err != nil {
+		t.Error(err)
+	}
+
+	s, err := ListFrom[string](d)
+	if err != nil {
+		t.Error(err)
+	}
+	if value, err := s.Get(1); err != nil {
+		t.Error(err)
+	} else if value != "b" {
+		t.Fatalf("Got %s, was expecting b", value)
+	}
+
+	if _, err := ListFrom[int](d); err == nil {
+		t.Fatal("Expected error converting list of strings to list of ints")
+	}
+
+	back, err := s.Dynamic()
+	if err != nil {
+		t.Error(err)
+	}
+	if equals, err := back.Equals(d); err != nil {
+		t.Error(err)
+	} else if equals == false {
+		t.Fatal("The converted list should equal the original, but it does not")
+	}
+}
@@ -0,0 +1,159 @@
+package typed
+
+import "github.com/dynago/dg/set"
+
+// Set is a set whose elements are all of type T.
+type Set[T comparable] struct {
+	set set.SetInterface
+}
+
+/* Length returns the number of elements in set. */
+func (s *Set[T]) Length() int {
+	return s.set.Length()
+}
+
+/* Iterate returns the next element in set. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (s *Set[T]) Iterate() <-chan T {
+	return channel[T](s.ForEach)
+}
+
+/* ForEach calls the function on each element in set until it returns false. */
+func (s *Set[T]) ForEach(fn func(T) bool) {
+	forEach(s.set, fn)
+}
+
+/* Iterator returns an Iterator over a snapshot of the elements in set. */
+func (s *Set[T]) Iterator() Iterator[T] {
+	return &iterator[T]{s.set.Iterator()}
+}
+
+/* Add adds element to the set. */
+func (s *Set[T]) Add(value T) error {
+	return s.set.Add(value)
+}
+
+/* Remove removes element from the set. */
+func (s *Set[T]) Remove(value T) error {
+	return s.set.Remove(value)
+}
+
+/* Combine updates the set, adding elements from the other set. */
+func (s *Set[T]) Combine(other *Set[T]) error {
+	return s.set.Combine(other.set)
+}
+
+/* Pop pops and return an arbitrary element from the set. */
+func (s *Set[T]) Pop() (T, error) {
+	value, err := s.set.Pop()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return cast[T](value)
+}
+
+/* Clear clears all elements from the set. */
+func (s *Set[T]) Clear() error {
+	return s.set.Clear()
+}
+
+/* Contains tests for membership in the set. */
+func (s *Set[T]) Contains(value T) (bool, error) {
+	return s.set.Contains(value)
+}
+
+/* Disjoint returns true if the set has no elements in common with the other set. */
+func (s *Set[T]) Disjoint(other *Set[T]) (bool, error) {
+	return s.set.Disjoint(other.set)
+}
+
+/* Equals returns true if the set has all elements in common with the other set. */
+func (s *Set[T]) Equals(other *Set[T]) (bool, error) {
+	return s.set.Equals(other.set)
+}
+
+/* SupersetOf tests whether every element in the other set is in the set. */
+func (s *Set[T]) SupersetOf(other *Set[T]) (bool, error) {
+	return s.set.SupersetOf(other.set)
+}
+
+/* SubsetOf tests whether every element in the set is in the other set. */
+func (s *Set[T]) SubsetOf(other *Set[T]) (bool, error) {
+	return s.set.SubsetOf(other.set)
+}
+
+/* Intersection returns a new set with elements common to the set and all others. */
+func (s *Set[T]) Intersection(other *Set[T]) (*Set[T], error) {
+	output, err := s.set.Intersection(other.set)
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* SymmetricDifference returns a new set with elements in either the set or the other but not both. */
+func (s *Set[T]) SymmetricDifference(other *Set[T]) (*Set[T], error) {
+	output, err := s.set.SymmetricDifference(other.set)
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* Difference returns a new set with elements in the set that are not in the other set. */
+func (s *Set[T]) Difference(other *Set[T]) (*Set[T], error) {
+	output, err := s.set.Difference(other.set)
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* Union returns a new set with elements from the set and the other set. */
+func (s *Set[T]) Union(other *Set[T]) (*Set[T], error) {
+	output, err := s.set.Union(other.set)
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* Copy creates a copy of the current set. */
+func (s *Set[T]) Copy() (*Set[T], error) {
+	output, err := s.set.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* String returns a string representation of the set. */
+func (s *Set[T]) String() string {
+	return s.set.String()
+}
+
+/* Dynamic returns a copy of the set as a dynamic set. */
+func (s *Set[T]) Dynamic() (set.SetInterface, error) {
+	return s.set.Copy()
+}
+
+/* MakeSet initializes a new typed set object using any number of values. */
+func MakeSet[T comparable](values ...T) (*Set[T], error) {
+	output, err := set.MakeSetFromValues(toInterfaces(values)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
+
+/* SetFrom initializes a new typed set object with the elements of a dynamic set. Returns an error if any element is not a T. */
+func SetFrom[T comparable](s set.SetInterface) (*Set[T], error) {
+	if err := checkAll[T](s); err != nil {
+		return nil, err
+	}
+	output, err := s.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Set[T]{output}, nil
+}
@@ -0,0 +1,57 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/dynago/dg/set"
+)
+
+func TestSet(t *testing.T) {
+	s1, err := MakeSet("a", "b", "a")
+	if err != nil {
+		t.Error(err)
+	}
+	s2, err := MakeSet("b", "c")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if s1.Length() != 2 {
+		t.Fatalf("Expected the length of the set to be 2, got %d", s1.Length())
+	}
+
+	if u, err := s1.Union(s2); err != nil {
+		t.Error(err)
+	} else if u.Length() != 3 {
+		t.Fatalf("Expected the length of the union to be 3, got %d", u.Length())
+	}
+
+	if i, err := s1.Intersection(s2); err != nil {
+		t.Error(err)
+	} else if value, err := i.Pop(); err != nil {
+		t.Error(err)
+	} else if value != "b" {
+		t.Fatalf("Got %s, was expecting b", value)
+	}
+}
+
+func TestSetConversion(t *testing.T) {
+	d, err := set.MakeSetFromValues(1, 2)
+	if err != nil {
+		t.Error(err)
+	}
+
+	s, err := SetFrom[int](d)
+	if err != nil {
+		t.Error(err)
+	}
+	if contains, err := s.Contains(2); err != nil {
+		t.Error(err)
+	} else if contains == false {
+		t.Fatal("The set does not contain 2 when it should")
+	}
+
+	if _, err := SetFrom[string](d); err == nil {
+		t.Fatal("Expected error converting set of ints to set of strings")
+	}
+}
@@ -0,0 +1,177 @@
+package typed
+
+import (
+	"fmt"
+
+	"github.com/dynago/dg/tuple"
+)
+
+// Tuple is a tuple whose elements are all of type T.
+type Tuple[T any] struct {
+	tuple tuple.TupleInterface
+}
+
+/* Length returns the number of elements in the tuple. */
+func (t *Tuple[T]) Length() int {
+	return t.tuple.Length()
+}
+
+/* Iterate returns the next value in the tuple. The channel must be read until it is closed; use ForEach or Iterator to stop early. */
+func (t *Tuple[T]) Iterate() <-chan T {
+	return channel[T](t.ForEach)
+}
+
+/* ForEach calls the function on each value in the tuple until it returns false. */
+func (t *Tuple[T]) ForEach(fn func(T) bool) {
+	forEach(t.tuple, fn)
+}
+
+/* Iterator returns an Iterator over the values in the tuple. */
+func (t *Tuple[T]) Iterator() Iterator[T] {
+	return &iterator[T]{t.tuple.Iterator()}
+}
+
+/* Contains tests for membership in the tuple. */
+func (t *Tuple[T]) Contains(value T) (bool, error) {
+	return t.tuple.Contains(value)
+}
+
+/* Equals returns true if the tuple has all elements in common with the other tuple. */
+func (t *Tuple[T]) Equals(other *Tuple[T]) (bool, error) {
+	return t.tuple.Equals(other.tuple)
+}
+
+/* Concatenate returns concatenation of two tuples together. */
+func (t *Tuple[T]) Concatenate(other *Tuple[T]) (*Tuple[T], error) {
+	output, err := t.tuple.Concatenate(other.tuple)
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* Multiply returns tuple repeated n times. */
+func (t *Tuple[T]) Multiply(n int) (*Tuple[T], error) {
+	output, err := t.tuple.Multiply(n)
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* Get returns the value at index. */
+func (t *Tuple[T]) Get(i int) (T, error) {
+	value, err := t.tuple.Get(i)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return cast[T](value)
+}
+
+/* Range returns the a tuple of values given range. */
+func (t *Tuple[T]) Range(start int, end int) (*Tuple[T], error) {
+	output, err := t.tuple.Range(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* Slice returns a tuple of every step-th value from start up to but not including stop, with the same semantics as Python's t[start:stop:step]. */
+func (t *Tuple[T]) Slice(start int, stop int, step int) (*Tuple[T], error) {
+	output, err := t.tuple.Slice(start, stop, step)
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* Index returns first index of value. Returns -1 if not found. */
+func (t *Tuple[T]) Index(value T) (int, error) {
+	return t.tuple.Index(value)
+}
+
+/* Count returns count of value. */
+func (t *Tuple[T]) Count(value T) (int, error) {
+	return t.tuple.Count(value)
+}
+
+/* Copy creates a copy of the current tuple. */
+func (t *Tuple[T]) Copy() (*Tuple[T], error) {
+	output, err := t.tuple.Copy()
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* String returns a string representation of the tuple. */
+func (t *Tuple[T]) String() string {
+	return t.tuple.String()
+}
+
+/* Dynamic returns the tuple as a dynamic tuple. Tuples are immutable, so it is not copied. */
+func (t *Tuple[T]) Dynamic() (tuple.TupleInterface, error) {
+	return t.tuple, nil
+}
+
+/* MakeTuple initializes a new typed tuple object using any number of values. */
+func MakeTuple[T any](values ...T) (*Tuple[T], error) {
+	output, err := tuple.MakeTupleFromValues(toInterfaces(values)...)
+	if err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{output}, nil
+}
+
+/* TupleFrom initializes a new typed tuple object with the values of a dynamic tuple. Returns an error if any value is not a T. */
+func TupleFrom[T any](t tuple.TupleInterface) (*Tuple[T], error) {
+	if err := checkAll[T](t); err != nil {
+		return nil, err
+	}
+	return &Tuple[T]{t}, nil
+}
+
+// Pair is a typed tuple of two values of different types.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+/* String returns a string representation of the pair. */
+func (p Pair[A, B]) String() string {
+	t, err := p.Dynamic()
+	if err != nil {
+		return ""
+	}
+	return t.String()
+}
+
+/* Dynamic returns the pair as a dynamic tuple of length 2. */
+func (p Pair[A, B]) Dynamic() (tuple.TupleInterface, error) {
+	return tuple.MakeTupleFromValues(p.First, p.Second)
+}
+
+/* PairFrom initializes a new pair with the values of a dynamic tuple of length 2. */
+func PairFrom[A any, B any](t tuple.TupleInterface) (Pair[A, B], error) {
+	var output Pair[A, B]
+	if t.Length() != 2 {
+		return output, fmt.Errorf("Tuple must be of length 2, got %d", t.Length())
+	}
+	first, err := t.Get(0)
+	if err != nil {
+		return output, err
+	}
+	second, err := t.Get(1)
+	if err != nil {
+		return output, err
+	}
+	if output.First, err = cast[A](first); err != nil {
+		return output, err
+	}
+	if output.Second, err = cast[B](second); err != nil {
+		return output, err
+	}
+	return output, nil
+}
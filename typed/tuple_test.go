@@ -0,0 +1,51 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/dynago/dg/tuple"
+)
+
+func TestTuple(t *testing.T) {
+	s, err := MakeTuple(1.5, 2.5)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if value, err := s.Get(0); err != nil {
+		t.Error(err)
+	} else if value != 1.5 {
+		t.Fatalf("Got %v, was expecting 1.5", value)
+	}
+
+	iter := s.Iterator()
+	defer iter.Close()
+	sum := 0.0
+	for iter.Next() {
+		sum += iter.Value()
+	}
+	if sum != 4 {
+		t.Fatalf("Got %v, was expecting 4", sum)
+	}
+}
+
+func TestPair(t *testing.T) {
+	p := Pair[string, int]{"a", 1}
+	if p.String() != "(a 1)" {
+		t.Fatalf("Got %s, which was unexpected", p.String())
+	}
+
+	d, err := tuple.MakeTupleFromValues("b", 2)
+	if err != nil {
+		t.Error(err)
+	}
+	if p, err = PairFrom[string, int](d); err != nil {
+		t.Error(err)
+	} else if p.First != "b" || p.Second != 2 {
+		t.Fatalf("Got %v, which was unexpected", p)
+	}
+
+	if _, err := PairFrom[int, int](d); err == nil {
+		t.Fatal("Expected error converting (b 2) to a pair of ints")
+	}
+}
@@ -0,0 +1,97 @@
+// Package typed implements generic versions of the dg structures, whose elements all share a static type.
+//
+// Each typed structure wraps its dynamic counterpart and offers the same methods with typed arguments and results. The typed structures convert to the dynamic interfaces with Dynamic, and from them with ListFrom, SetFrom, DictFrom and TupleFrom.
+package typed
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dynago/dg/iterable"
+)
+
+// Iterator is a pull-style iterator over values of type T.
+type Iterator[T any] interface {
+	/* Advance to the next value. Returns false when there are no more values. */
+	Next() bool
+	/* Return the current value. */
+	Value() T
+	/* Release the iterator. It is safe to call more than once. */
+	Close()
+}
+
+// iterator is an Iterator which casts the values of a dynamic iterator.
+type iterator[T any] struct {
+	iter iterable.Iterator
+}
+
+func (i *iterator[T]) Next() bool {
+	return i.iter.Next()
+}
+
+func (i *iterator[T]) Value() T {
+	value, _ := cast[T](i.iter.Value())
+	return value
+}
+
+func (i *iterator[T]) Close() {
+	i.iter.Close()
+}
+
+/* channel returns a channel which receives every value passed to the function by forEach. */
+func channel[T any](forEach func(func(T) bool)) <-chan T {
+	c := make(chan T)
+	go func() {
+		forEach(func(value T) bool {
+			c <- value
+			return true
+		})
+		close(c)
+	}()
+	return c
+}
+
+/* forEach calls fn on each value of the dynamic iterable, cast to T, until fn returns false. */
+func forEach[T any](it iterable.ForEacher, fn func(T) bool) {
+	it.ForEach(func(value interface{}) bool {
+		v, _ := cast[T](value)
+		return fn(v)
+	})
+}
+
+/* cast returns the value as a T. A nil value is the zero value of T if T can be nil. */
+func cast[T any](value interface{}) (T, error) {
+	var zero T
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if value == nil {
+		switch typ.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return zero, nil
+		}
+		return zero, fmt.Errorf("Value nil is not of type %v", typ)
+	}
+	v, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("Value %v is of type %T, not %v", value, value, typ)
+	}
+	return v, nil
+}
+
+/* toInterfaces returns the values as a slice of interface{}. */
+func toInterfaces[T any](values []T) []interface{} {
+	output := make([]interface{}, len(values))
+	for i, value := range values {
+		output[i] = value
+	}
+	return output
+}
+
+/* checkAll returns an error if any value of the dynamic iterable is not a T. */
+func checkAll[T any](it iterable.ForEacher) error {
+	var err error
+	it.ForEach(func(value interface{}) bool {
+		_, err = cast[T](value)
+		return err == nil
+	})
+	return err
+}